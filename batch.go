@@ -0,0 +1,142 @@
+package cpfcnpj
+
+import (
+	"context"
+	"iter"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// BatchOptions configures ValidateBatch and ValidateSeq.
+type BatchOptions struct {
+	// Workers is the number of validating goroutines. Zero or negative uses runtime.GOMAXPROCS(0).
+	Workers int
+
+	// Kind selects the validator: KindCPF uses NewCpf, either CNPJ kind uses NewCnpj and
+	// KindUnknown (the zero value) detects the type with Parse.
+	Kind Kind
+}
+
+// Result is the outcome of validating one input of a batch.
+type Result struct {
+	// Index is the position of the input in the batch.
+	Index int
+	// Input is the input exactly as given.
+	Input string
+	// Document is the validated document, or nil if Err is set.
+	Document Document
+	// Err is the validation error, or ctx.Err() for items skipped after cancellation.
+	Err error
+}
+
+// workers returns the number of goroutines to use.
+func (o BatchOptions) workers() int {
+	if o.Workers > 0 {
+		return o.Workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// validate validates one input with the validator selected by Kind.
+func (o BatchOptions) validate(ctx context.Context, index int, input string) Result {
+	result := Result{Index: index, Input: input}
+	if err := ctx.Err(); err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.Document, result.Err = ParseKind(o.Kind, input)
+	return result
+}
+
+// ValidateBatch validates inputs on a bounded pool of goroutines and returns one Result per input,
+// in input order. Errors wrap the same sentinels as NewCpf, NewCnpj and Parse.
+// If ctx is cancelled, the inputs not yet validated get ctx.Err() as their error.
+func ValidateBatch(ctx context.Context, inputs []string, opts BatchOptions) []Result {
+	results := make([]Result, len(inputs))
+	workers := min(opts.workers(), len(inputs))
+
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(inputs) {
+					return
+				}
+				results[i] = opts.validate(ctx, i, inputs[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// ValidateSeq validates inputs as they are produced, on a bounded pool of goroutines, and yields
+// results in input order. At most a few items per worker are held in memory, so it suits inputs
+// too large to load at once.
+//
+// Inputs are read from a separate goroutine. When ctx is cancelled or the caller stops iterating,
+// no more inputs are read; items already read are yielded with ctx.Err() until the sequence ends.
+func ValidateSeq(ctx context.Context, inputs iter.Seq[string], opts BatchOptions) iter.Seq[Result] {
+	return func(yield func(Result) bool) {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		type job struct {
+			index int
+			input string
+			done  chan Result
+		}
+
+		workers := opts.workers()
+		jobs := make(chan job)
+		pending := make(chan chan Result, workers)
+
+		for range workers {
+			go func() {
+				for j := range jobs {
+					j.done <- opts.validate(ctx, j.index, j.input)
+				}
+			}()
+		}
+
+		go func() {
+			defer close(jobs)
+			defer close(pending)
+
+			index := 0
+			for input := range inputs {
+				// select picks among ready cases at random, so check ctx first to stop reading promptly
+				if ctx.Err() != nil {
+					return
+				}
+				j := job{index: index, input: input, done: make(chan Result, 1)}
+				select {
+				case pending <- j.done:
+				case <-ctx.Done():
+					return
+				}
+				select {
+				case jobs <- j:
+				case <-ctx.Done():
+					j.done <- Result{Index: j.index, Input: j.input, Err: ctx.Err()}
+					return
+				}
+				index++
+			}
+		}()
+
+		// Results are received in the order the jobs were queued, which is input order
+		for done := range pending {
+			if !yield(<-done) {
+				return
+			}
+		}
+	}
+}
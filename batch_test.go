@@ -0,0 +1,213 @@
+package cpfcnpj
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// batchInputs mixes valid and invalid documents of every kind
+var batchInputs = []string{
+	"716.566.867-59",
+	"22.796.729/0001-59",
+	"12ABC34501DE35",
+	"716.566.867-58",
+	"12345",
+	"",
+	"648.446.967-93",
+	"12.ABC.345/01DE-99",
+}
+
+// Test batch validation results, order and error sentinels
+func TestValidateBatch(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         BatchOptions
+		expectedErrs []error
+	}{
+		{
+			name: "Auto-detect",
+			opts: BatchOptions{},
+			expectedErrs: []error{nil, nil, nil, ErrCPFInvalidChecksum, ErrUnknownDocument, ErrUnknownDocument,
+				nil, ErrCNPJInvalidChecksum},
+		},
+		{
+			name: "CPF only, single worker",
+			opts: BatchOptions{Kind: KindCPF, Workers: 1},
+			expectedErrs: []error{nil, ErrCPFInvalidLength, ErrCPFInvalidLength, ErrCPFInvalidChecksum,
+				ErrCPFInvalidLength, ErrCPFInvalidLength, nil, ErrCPFInvalidLength},
+		},
+		{
+			name: "CNPJ only, many workers",
+			opts: BatchOptions{Kind: KindCNPJNumeric, Workers: 64},
+			expectedErrs: []error{ErrCNPJInvalidLength, nil, nil, ErrCNPJInvalidLength, ErrCNPJInvalidLength,
+				ErrCNPJInvalidLength, ErrCNPJInvalidLength, ErrCNPJInvalidChecksum},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := ValidateBatch(context.Background(), batchInputs, tt.opts)
+			if len(results) != len(batchInputs) {
+				t.Fatalf("ValidateBatch returned %d results, want %d", len(results), len(batchInputs))
+			}
+
+			for i, r := range results {
+				if r.Index != i || r.Input != batchInputs[i] {
+					t.Errorf("results[%d] = {Index: %d, Input: %q}, want {Index: %d, Input: %q}",
+						i, r.Index, r.Input, i, batchInputs[i])
+				}
+				if !errors.Is(r.Err, tt.expectedErrs[i]) || (r.Err == nil) != (tt.expectedErrs[i] == nil) {
+					t.Errorf("results[%d].Err = %v, want %v", i, r.Err, tt.expectedErrs[i])
+				}
+				if (r.Document == nil) != (r.Err != nil) {
+					t.Errorf("results[%d].Document = %v with Err = %v", i, r.Document, r.Err)
+				}
+			}
+		})
+	}
+}
+
+// Test that a cancelled context marks the remaining inputs
+func TestValidateBatch_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := ValidateBatch(ctx, batchInputs, BatchOptions{})
+	for i, r := range results {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("results[%d].Err = %v, want %v", i, r.Err, context.Canceled)
+		}
+	}
+
+	if results := ValidateBatch(context.Background(), nil, BatchOptions{}); len(results) != 0 {
+		t.Errorf("ValidateBatch(nil) returned %d results, want 0", len(results))
+	}
+}
+
+// Test that a large batch keeps input order
+func TestValidateBatch_Order(t *testing.T) {
+	inputs := make([]string, 5000)
+	for i := range inputs {
+		inputs[i] = batchInputs[i%len(batchInputs)]
+	}
+
+	results := ValidateBatch(context.Background(), inputs, BatchOptions{Workers: 8})
+	for i, r := range results {
+		if r.Index != i || r.Input != inputs[i] {
+			t.Fatalf("results[%d] out of order: %+v", i, r)
+		}
+	}
+}
+
+// Test that the streaming variant yields the same results as ValidateBatch
+func TestValidateSeq(t *testing.T) {
+	inputs := make([]string, 1000)
+	for i := range inputs {
+		inputs[i] = batchInputs[i%len(batchInputs)]
+	}
+	expected := ValidateBatch(context.Background(), inputs, BatchOptions{})
+
+	i := 0
+	for r := range ValidateSeq(context.Background(), slices.Values(inputs), BatchOptions{Workers: 4}) {
+		if r.Index != i || r.Input != expected[i].Input || fmt.Sprint(r.Err) != fmt.Sprint(expected[i].Err) {
+			t.Fatalf("result %d = %+v, want %+v", i, r, expected[i])
+		}
+		i++
+	}
+	if i != len(inputs) {
+		t.Errorf("ValidateSeq yielded %d results, want %d", i, len(inputs))
+	}
+}
+
+// Test stopping the iteration early
+func TestValidateSeq_Break(t *testing.T) {
+	endless := func(yield func(string) bool) {
+		for {
+			if !yield("716.566.867-59") {
+				return
+			}
+		}
+	}
+
+	count := 0
+	for r := range ValidateSeq(context.Background(), endless, BatchOptions{Workers: 2}) {
+		if r.Err != nil {
+			t.Fatalf("unexpected error: %v", r.Err)
+		}
+		count++
+		if count == 100 {
+			break
+		}
+	}
+	if count != 100 {
+		t.Errorf("got %d results, want 100", count)
+	}
+}
+
+// Test that cancellation ends the sequence
+func TestValidateSeq_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	endless := func(yield func(string) bool) {
+		for {
+			if !yield("22796729000159") {
+				return
+			}
+		}
+	}
+
+	count := 0
+	for r := range ValidateSeq(ctx, endless, BatchOptions{Workers: 2}) {
+		count++
+		if count == 10 {
+			cancel()
+		}
+		if count > 10 && r.Err != nil && !errors.Is(r.Err, context.Canceled) {
+			t.Fatalf("unexpected error after cancel: %v", r.Err)
+		}
+	}
+	if count < 10 {
+		t.Errorf("got %d results, want at least 10", count)
+	}
+}
+
+// Test that no more inputs are read once ctx is cancelled
+func TestValidateSeq_StopsReading(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	read := 0
+	inputs := func(yield func(string) bool) {
+		for {
+			read++
+			if read == 2 {
+				cancel()
+			}
+			if !yield("716.566.867-59") {
+				return
+			}
+		}
+	}
+
+	for range ValidateSeq(ctx, inputs, BatchOptions{Workers: 4}) {
+	}
+	if read != 2 {
+		t.Errorf("read %d inputs, want 2: reading must stop at the first input after cancellation", read)
+	}
+}
+
+// Benchmark batch validation
+func BenchmarkValidateBatch(b *testing.B) {
+	inputs := make([]string, 10000)
+	for i := range inputs {
+		inputs[i] = batchInputs[i%len(batchInputs)]
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = ValidateBatch(context.Background(), inputs, BatchOptions{})
+	}
+}
@@ -0,0 +1,43 @@
+// Package example holds structs with cpfcnpj tags and the methods cpfcnpj-gen generates for
+// them. Its tests check that the generated code agrees with cpfcnpj.ValidateStruct.
+package example
+
+//go:generate go run github.com/n0vdd/cpf_cnpj/cmd/cpfcnpj-gen
+
+// Address is held by value in Company.
+type Address struct {
+	Owner string `cpfcnpj:"cpf,omitempty"`
+}
+
+// Company is held in slices and maps of Request.
+type Company struct {
+	CNPJ    string `cpfcnpj:"cnpj,normalize=formatted"`
+	Address Address
+}
+
+// registration is embedded in Request, so its fields are promoted.
+type registration struct {
+	Registration string `cpfcnpj:"any,normalize=raw"`
+}
+
+// Request uses every supported field shape.
+type Request struct {
+	registration
+	CPF       string            `cpfcnpj:"cpf,normalize=raw"`
+	Optional  *string           `cpfcnpj:"any,normalize=formatted"`
+	Payees    []string          `cpfcnpj:"any,normalize=raw"`
+	Aliases   map[string]string `cpfcnpj:"any,normalize=formatted"`
+	Companies []Company
+	Partners  []*Company
+	ByBranch  map[string]*Company
+	ByCode    map[int]Company
+	Billing   *Address
+	Ignored   string `cpfcnpj:"-"`
+	Untagged  string
+	Count     int
+}
+
+// Plain has no document fields and gets no methods.
+type Plain struct {
+	Name string
+}
@@ -0,0 +1,137 @@
+package example
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	cpfcnpj "github.com/n0vdd/cpf_cnpj"
+)
+
+// newRequest returns a Request with valid, unnormalized documents in every field shape
+func newRequest() *Request {
+	optional := "22796729000159"
+	return &Request{
+		registration: registration{Registration: "12.ABC.345/01DE-35"},
+		CPF:          "716.566.867-59",
+		Optional:     &optional,
+		Payees:       []string{"716.566.867-59", "22.796.729/0001-59"},
+		Aliases:      map[string]string{"b": "71656686759", "a": "12abc34501de35"},
+		Companies:    []Company{{CNPJ: "22796729000159", Address: Address{Owner: "71656686759"}}},
+		Partners:     []*Company{nil, {CNPJ: "22796729000159"}},
+		ByBranch:     map[string]*Company{"hq": {CNPJ: "22796729000159"}, "none": nil},
+		ByCode:       map[int]Company{7: {CNPJ: "12ABC34501DE35"}},
+		Billing:      &Address{},
+	}
+}
+
+// invalidRequest returns a Request with invalid documents in every field shape
+func invalidRequest() *Request {
+	r := newRequest()
+	r.Registration = "12.ABC.345/01DE-99"
+	r.CPF = ""
+	*r.Optional = "123"
+	r.Payees[1] = "22.796.729/0001-58"
+	r.Aliases["c"] = "71656686758"
+	r.Companies[0].Address.Owner = "11111111111"
+	r.Partners[1].CNPJ = "1234"
+	r.ByBranch["branch"] = &Company{CNPJ: "22796729000158"}
+	r.ByCode[3] = Company{CNPJ: "12ABC34501DE99"}
+	r.Billing.Owner = "71656686758"
+	return r
+}
+
+// Test that Validate reports the same field errors as ValidateStruct
+func TestValidate_MatchesValidateStruct(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *Request
+	}{
+		{"Valid", newRequest()},
+		{"Invalid", invalidRequest()},
+		{"Zero", &Request{}},
+		{"Int keys in numeric order", &Request{ByCode: map[int]Company{
+			10: {CNPJ: "22796729000158"},
+			2:  {CNPJ: "12ABC34501DE99"},
+		}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// ValidateStruct gets a value so it does not normalize
+			expected := cpfcnpj.ValidateStruct(*tt.request)
+			got := tt.request.Validate()
+
+			if (got == nil) != (expected == nil) {
+				t.Fatalf("Validate() = %v, ValidateStruct() = %v", got, expected)
+			}
+			if got == nil {
+				return
+			}
+
+			var gotErrs, expectedErrs cpfcnpj.FieldErrors
+			if !errors.As(got, &gotErrs) || !errors.As(expected, &expectedErrs) {
+				t.Fatalf("Validate() = %T, ValidateStruct() = %T, want FieldErrors", got, expected)
+			}
+			if len(gotErrs) != len(expectedErrs) {
+				t.Fatalf("Validate() = %v\nValidateStruct() = %v", got, expected)
+			}
+			for i := range gotErrs {
+				if gotErrs[i].Path != expectedErrs[i].Path || gotErrs[i].Err.Error() != expectedErrs[i].Err.Error() ||
+					!errors.Is(gotErrs[i], errors.Unwrap(expectedErrs[i].Err)) {
+					t.Errorf("field error %d = %v, want %v", i, gotErrs[i], expectedErrs[i])
+				}
+			}
+		})
+	}
+}
+
+// Test that the invalid request reports the library sentinels
+func TestValidate_Sentinels(t *testing.T) {
+	err := invalidRequest().Validate()
+	for _, sentinel := range []error{cpfcnpj.ErrCNPJInvalidChecksum, cpfcnpj.ErrCPFInvalidLength,
+		cpfcnpj.ErrUnknownDocument, cpfcnpj.ErrCPFInvalidChecksum, cpfcnpj.ErrAllSameDigits,
+		cpfcnpj.ErrCNPJInvalidLength} {
+		if !errors.Is(err, sentinel) {
+			t.Errorf("errors.Is(err, %v) = false", sentinel)
+		}
+	}
+}
+
+// Test that Normalize rewrites fields exactly like ValidateStruct
+func TestNormalize_MatchesValidateStruct(t *testing.T) {
+	for name, newRequest := range map[string]func() *Request{"Valid": newRequest, "Invalid": invalidRequest} {
+		t.Run(name, func(t *testing.T) {
+			got, expected := newRequest(), newRequest()
+			got.Normalize()
+			_ = cpfcnpj.ValidateStruct(expected)
+
+			if !reflect.DeepEqual(got, expected) {
+				t.Errorf("Normalize() = %+v\nValidateStruct() = %+v", got, expected)
+			}
+		})
+	}
+}
+
+// Benchmark generated validation against reflection
+func BenchmarkValidate(b *testing.B) {
+	r := newRequest()
+	r.Normalize()
+
+	b.Run("Generated", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := r.Validate(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("ValidateStruct", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := cpfcnpj.ValidateStruct(r); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
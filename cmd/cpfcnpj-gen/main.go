@@ -0,0 +1,620 @@
+// Command cpfcnpj-gen generates Validate and Normalize methods for structs with cpfcnpj-tagged
+// fields, so hot paths get the checks of cpfcnpj.ValidateStruct without reflection.
+//
+// Usage:
+//
+//	//go:generate go run github.com/n0vdd/cpf_cnpj/cmd/cpfcnpj-gen [-type T1,T2] [-output file]
+//
+// cpfcnpj-gen reads the non-test Go files of the current directory, or of the directory given
+// as argument. By default it generates methods for every struct with tagged fields and for every
+// struct that holds such structs; -type restricts it to the listed types.
+//
+// Tags have the same syntax as for cpfcnpj.ValidateStruct. Tagged fields must be string, *string,
+// []string or map[K]string with an ordered key type K. For each type T it generates:
+//
+//	func (x *T) Validate() error // cpfcnpj.FieldErrors with the same paths as ValidateStruct
+//	func (x *T) Normalize()      // rewrites the valid fields tagged normalize=raw|formatted
+//
+// Untagged fields holding generated structs (T, *T, []T, []*T, map[K]T and map[K]*T) are walked
+// like ValidateStruct walks them. Unlike ValidateStruct, interface fields are not walked and
+// pointer cycles are not detected.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Exit codes
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// defaultOutput is the name of the generated file.
+const defaultOutput = "cpfcnpj_gen.go"
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stderr))
+}
+
+// run executes the command line args and returns the exit code.
+func run(args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("cpfcnpj-gen", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	typeList := fs.String("type", "",
+		"comma-separated struct types to generate methods for (default: all tagged structs)")
+	output := fs.String("output", defaultOutput, "output file name, relative to the package directory")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() > 1 {
+		warnf(stderr, "cpfcnpj-gen: takes at most one package directory\n")
+		return exitUsage
+	}
+
+	dir := "."
+	if fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	}
+	var typeNames []string
+	if *typeList != "" {
+		typeNames = strings.Split(*typeList, ",")
+	}
+
+	outPath := filepath.Join(dir, *output)
+	src, err := generateDir(dir, outPath, typeNames)
+	if err == nil {
+		err = os.WriteFile(outPath, src, 0o644)
+	}
+	if err != nil {
+		warnf(stderr, "cpfcnpj-gen: %v\n", err)
+		return exitError
+	}
+	return exitOK
+}
+
+// warnf prints a diagnostic to stderr. A failed write to stderr has nowhere left to be reported.
+func warnf(stderr io.Writer, format string, args ...any) {
+	fmt.Fprintf(stderr, format, args...) //nolint:errcheck // nowhere left to report the error
+}
+
+// generateDir parses the non-test Go files of dir, except the previous output, and generates
+// the methods for typeNames.
+func generateDir(dir, outPath string, typeNames []string) ([]byte, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") || filepath.Clean(name) == filepath.Clean(outPath) {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	return generate(files, typeNames)
+}
+
+// tagInfo is a parsed cpfcnpj struct tag.
+type tagInfo struct {
+	ctor      string // cpfcnpj.NewCpf, cpfcnpj.NewCnpj or cpfcnpj.Parse
+	normalize string // "", "Raw" or "String": the method producing the normalized value
+	omitEmpty bool
+}
+
+// shape is how a field holds its strings or structs.
+type shape int
+
+const (
+	shapeValue   shape = iota // T
+	shapePointer              // *T
+	shapeSlice                // []T or []*T
+	shapeMap                  // map[K]T or map[K]*T
+)
+
+// field is a struct field the generated methods look at.
+type field struct {
+	name     string
+	embedded bool
+	shape    shape
+	tag      *tagInfo // nil for fields holding generated structs
+	elemPtr  bool     // slice and map elements are pointers to structs
+	elemType string   // the struct type of fields holding generated structs
+}
+
+// structInfo is a struct type found in the package.
+type structInfo struct {
+	name   string
+	fields []field
+	nested map[string]bool // struct types referenced by untagged fields
+}
+
+// generate returns the formatted source of the methods for typeNames, or for every struct with
+// tagged fields and every struct holding them when typeNames is empty.
+func generate(files []*ast.File, typeNames []string) ([]byte, error) {
+	pkg := files[0].Name.Name
+	structs := map[string]*structInfo{}
+	var order []string
+
+	for _, f := range files {
+		if f.Name.Name != pkg {
+			return nil, fmt.Errorf("found packages %s and %s", pkg, f.Name.Name)
+		}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok || ts.TypeParams != nil {
+					continue
+				}
+				info, err := parseStruct(ts.Name.Name, st)
+				if err != nil {
+					return nil, err
+				}
+				structs[info.name] = info
+				order = append(order, info.name)
+			}
+		}
+	}
+
+	selected, err := selectTypes(structs, order, typeNames)
+	if err != nil {
+		return nil, err
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no structs with cpfcnpj tags in package %s", pkg)
+	}
+
+	// Only fields holding selected structs get nested calls; the others have no methods
+	for _, name := range selected {
+		info := structs[name]
+		info.fields = slices.DeleteFunc(info.fields, func(f field) bool {
+			return f.tag == nil && !slices.Contains(selected, f.elemType)
+		})
+	}
+
+	var g generator
+	for _, name := range selected {
+		g.writeStruct(structs[name])
+	}
+	return g.source(pkg)
+}
+
+// parseStruct collects the tagged fields of a struct and the untagged fields that may hold structs.
+func parseStruct(name string, st *ast.StructType) (*structInfo, error) {
+	info := &structInfo{name: name, nested: map[string]bool{}}
+
+	for _, f := range st.Fields.List {
+		names := f.Names
+		embedded := len(names) == 0
+		if embedded {
+			ident := embeddedName(f.Type)
+			if ident == nil {
+				continue
+			}
+			names = []*ast.Ident{ident}
+		}
+
+		var tag string
+		var tagged bool
+		if f.Tag != nil {
+			raw, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: malformed tag %s", name, f.Tag.Value)
+			}
+			tag, tagged = reflect.StructTag(raw).Lookup("cpfcnpj")
+		}
+		if tag == "-" {
+			continue
+		}
+
+		for _, n := range names {
+			// Like ValidateStruct, skip unexported fields except embedded ones
+			if !n.IsExported() && !embedded {
+				continue
+			}
+			fieldName := name + "." + n.Name
+
+			if tagged {
+				ti, err := parseTag(tag)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", fieldName, err)
+				}
+				s, ok := stringShape(f.Type)
+				if !ok {
+					return nil, fmt.Errorf("%s: cpfcnpj tag on unsupported type %s, want string, *string, "+
+						"[]string or map[K]string with an ordered K", fieldName, types.ExprString(f.Type))
+				}
+				info.fields = append(info.fields, field{name: n.Name, shape: s, tag: &ti})
+				continue
+			}
+
+			if fl, ok := structShape(f.Type); ok {
+				fl.name = n.Name
+				fl.embedded = embedded
+				info.fields = append(info.fields, fl)
+				info.nested[fl.elemType] = true
+			}
+		}
+	}
+	return info, nil
+}
+
+// selectTypes returns the structs to generate methods for, in declaration order.
+func selectTypes(structs map[string]*structInfo, order, typeNames []string) ([]string, error) {
+	if len(typeNames) > 0 {
+		for _, name := range typeNames {
+			if structs[name] == nil {
+				return nil, fmt.Errorf("type %s not found or not a struct", name)
+			}
+		}
+		return slices.DeleteFunc(slices.Clone(order), func(name string) bool {
+			return !slices.Contains(typeNames, name)
+		}), nil
+	}
+
+	selected := map[string]bool{}
+	for _, info := range structs {
+		for _, f := range info.fields {
+			if f.tag != nil {
+				selected[info.name] = true
+			}
+		}
+	}
+	// Add the structs holding selected structs until nothing changes
+	for changed := true; changed; {
+		changed = false
+		for _, info := range structs {
+			if selected[info.name] {
+				continue
+			}
+			for nested := range info.nested {
+				if selected[nested] {
+					selected[info.name] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+
+	return slices.DeleteFunc(slices.Clone(order), func(name string) bool { return !selected[name] }), nil
+}
+
+// parseTag parses a cpfcnpj tag such as "any,normalize=raw,omitempty".
+func parseTag(tag string) (tagInfo, error) {
+	name, opts, _ := strings.Cut(tag, ",")
+
+	var ti tagInfo
+	switch name {
+	case "cpf":
+		ti.ctor = "cpfcnpj.NewCpf"
+	case "cnpj":
+		ti.ctor = "cpfcnpj.NewCnpj"
+	case "any":
+		ti.ctor = "cpfcnpj.Parse"
+	default:
+		return ti, fmt.Errorf("invalid cpfcnpj tag %q: kind must be cpf, cnpj or any", tag)
+	}
+
+	for opt := range strings.SplitSeq(opts, ",") {
+		switch opt {
+		case "":
+		case "omitempty":
+			ti.omitEmpty = true
+		case "normalize=raw":
+			ti.normalize = "Raw"
+		case "normalize=formatted":
+			ti.normalize = "String"
+		default:
+			return ti, fmt.Errorf("invalid cpfcnpj tag %q: unknown option %q", tag, opt)
+		}
+	}
+	return ti, nil
+}
+
+// stringShape reports how a tagged field of type expr holds its strings.
+func stringShape(expr ast.Expr) (shape, bool) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return shapeValue, t.Name == "string"
+	case *ast.StarExpr:
+		return shapePointer, isIdent(t.X, "string")
+	case *ast.ArrayType:
+		return shapeSlice, t.Len == nil && isIdent(t.Elt, "string")
+	case *ast.MapType:
+		return shapeMap, isOrderedKey(t.Key) && isIdent(t.Value, "string")
+	}
+	return 0, false
+}
+
+// structShape reports whether an untagged field of type expr may hold a struct of the package,
+// returning the field with its shape and element type set.
+func structShape(expr ast.Expr) (field, bool) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return field{shape: shapeValue, elemType: t.Name}, !isPredeclared(t.Name)
+	case *ast.StarExpr:
+		if ident, ok := t.X.(*ast.Ident); ok {
+			return field{shape: shapePointer, elemType: ident.Name}, true
+		}
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return field{}, false
+		}
+		name, ptr, ok := elemStruct(t.Elt)
+		return field{shape: shapeSlice, elemType: name, elemPtr: ptr}, ok
+	case *ast.MapType:
+		name, ptr, ok := elemStruct(t.Value)
+		return field{shape: shapeMap, elemType: name, elemPtr: ptr}, ok && isOrderedKey(t.Key)
+	}
+	return field{}, false
+}
+
+// elemStruct returns the type name of a T or *T element.
+func elemStruct(expr ast.Expr) (name string, ptr bool, ok bool) {
+	if star, isStar := expr.(*ast.StarExpr); isStar {
+		expr, ptr = star.X, true
+	}
+	ident, isIdent := expr.(*ast.Ident)
+	if !isIdent || isPredeclared(ident.Name) {
+		return "", false, false
+	}
+	return ident.Name, ptr, true
+}
+
+// embeddedName returns the name of an embedded T or *T field, or nil for other embedded types.
+func embeddedName(expr ast.Expr) *ast.Ident {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	ident, _ := expr.(*ast.Ident)
+	return ident
+}
+
+// isIdent reports whether expr is the identifier name.
+func isIdent(expr ast.Expr, name string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == name
+}
+
+// isOrderedKey reports whether a map key type can be sorted with slices.Sorted.
+func isOrderedKey(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	switch ident.Name {
+	case "string", "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64",
+		"uintptr", "float32", "float64", "byte", "rune":
+		return true
+	}
+	return false
+}
+
+// isPredeclared reports whether name is a predeclared type, which can never be a generated struct.
+func isPredeclared(name string) bool {
+	return types.Universe.Lookup(name) != nil
+}
+
+// generator accumulates the generated methods.
+type generator struct {
+	buf        bytes.Buffer
+	usesFmt    bool
+	usesSorted bool
+}
+
+// printf writes formatted code.
+func (g *generator) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// source returns the formatted file.
+func (g *generator) source(pkg string) ([]byte, error) {
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by cpfcnpj-gen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg)
+	if g.usesFmt {
+		out.WriteString("\t\"fmt\"\n")
+	}
+	if g.usesSorted {
+		out.WriteString("\t\"maps\"\n\t\"slices\"\n")
+	}
+	out.WriteString("\n\tcpfcnpj \"github.com/n0vdd/cpf_cnpj\"\n)\n")
+	out.Write(g.buf.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+// writeStruct writes the methods of one struct.
+func (g *generator) writeStruct(info *structInfo) {
+	g.printf(`
+// Validate reports every invalid document in the cpfcnpj-tagged fields of x as
+// cpfcnpj.FieldErrors, with the same paths and errors as cpfcnpj.ValidateStruct.
+func (x *%[1]s) Validate() error {
+	var errs cpfcnpj.FieldErrors
+	x.cpfcnpjValidate("", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// cpfcnpjValidate appends the field errors of x to errs, prefixing their paths with prefix.
+func (x *%[1]s) cpfcnpjValidate(prefix string, errs *cpfcnpj.FieldErrors) {
+`, info.name)
+	for _, f := range info.fields {
+		g.writeValidateField(f)
+	}
+	g.printf("}\n")
+
+	g.printf(`
+// Normalize rewrites the valid documents of the fields tagged normalize=raw or
+// normalize=formatted. Invalid values are left unchanged.
+func (x *%s) Normalize() {
+`, info.name)
+	for _, f := range info.fields {
+		g.writeNormalizeField(f)
+	}
+	g.printf("}\n")
+}
+
+// writeValidateField writes the checks of one field in cpfcnpjValidate.
+func (g *generator) writeValidateField(f field) {
+	if f.tag == nil {
+		g.writeNested(f, "cpfcnpjValidate")
+		return
+	}
+	path := strconv.Quote(f.name)
+
+	check := func(value, path string) {
+		if f.tag.omitEmpty {
+			g.printf("if %s != \"\" {\n", value)
+		}
+		g.printf("if _, err := %s(%s); err != nil {\n", f.tag.ctor, value)
+		g.printf("*errs = append(*errs, &cpfcnpj.FieldError{Path: %s, Err: err})\n}\n", path)
+		if f.tag.omitEmpty {
+			g.printf("}\n")
+		}
+	}
+
+	switch f.shape {
+	case shapeValue:
+		check("x."+f.name, "prefix + "+path)
+	case shapePointer:
+		g.printf("if x.%s != nil {\n", f.name)
+		check("*x."+f.name, "prefix + "+path)
+		g.printf("}\n")
+	case shapeSlice:
+		g.usesFmt = true
+		g.printf("for i, v := range x.%s {\n", f.name)
+		check("v", fmt.Sprintf("fmt.Sprintf(\"%%s%s[%%d]\", prefix, i)", f.name))
+		g.printf("}\n")
+	case shapeMap:
+		g.usesFmt, g.usesSorted = true, true
+		g.printf("for _, k := range slices.Sorted(maps.Keys(x.%s)) {\n", f.name)
+		check(fmt.Sprintf("x.%s[k]", f.name), fmt.Sprintf("fmt.Sprintf(\"%%s%s[%%v]\", prefix, k)", f.name))
+		g.printf("}\n")
+	}
+}
+
+// writeNormalizeField writes the rewrites of one field in Normalize.
+func (g *generator) writeNormalizeField(f field) {
+	if f.tag == nil {
+		g.writeNested(f, "Normalize")
+		return
+	}
+	if f.tag.normalize == "" {
+		return
+	}
+
+	rewrite := func(value, target string) {
+		g.printf("if doc, err := %s(%s); err == nil {\n%s = doc.%s()\n}\n", f.tag.ctor, value, target, f.tag.normalize)
+	}
+
+	switch f.shape {
+	case shapeValue:
+		rewrite("x."+f.name, "x."+f.name)
+	case shapePointer:
+		g.printf("if x.%s != nil {\n", f.name)
+		rewrite("*x."+f.name, "*x."+f.name)
+		g.printf("}\n")
+	case shapeSlice:
+		g.printf("for i, v := range x.%s {\n", f.name)
+		rewrite("v", fmt.Sprintf("x.%s[i]", f.name))
+		g.printf("}\n")
+	case shapeMap:
+		g.printf("for k, v := range x.%s {\n", f.name)
+		rewrite("v", fmt.Sprintf("x.%s[k]", f.name))
+		g.printf("}\n")
+	}
+}
+
+// writeNested writes calls of method, cpfcnpjValidate or Normalize, on the generated structs held by f.
+func (g *generator) writeNested(f field, method string) {
+	validate := method == "cpfcnpjValidate"
+	call := func(recv, prefix string) {
+		if validate {
+			g.printf("%s.%s(%s, errs)\n", recv, method, prefix)
+		} else {
+			g.printf("%s.%s()\n", recv, method)
+		}
+	}
+	callElem := func(recv, prefix string) {
+		if f.elemPtr {
+			g.printf("if %s != nil {\n", recv)
+			call(recv, prefix)
+			g.printf("}\n")
+		} else {
+			call(recv, prefix)
+		}
+	}
+
+	fieldPrefix := "prefix + " + strconv.Quote(f.name+".")
+	if f.embedded {
+		// Embedded fields are promoted, so their paths do not mention them
+		fieldPrefix = "prefix"
+	}
+
+	switch f.shape {
+	case shapeValue:
+		call("x."+f.name, fieldPrefix)
+	case shapePointer:
+		g.printf("if x.%s != nil {\n", f.name)
+		call("x."+f.name, fieldPrefix)
+		g.printf("}\n")
+	case shapeSlice:
+		g.usesFmt = g.usesFmt || validate
+		g.printf("for i := range x.%s {\n", f.name)
+		callElem(fmt.Sprintf("x.%s[i]", f.name), fmt.Sprintf("fmt.Sprintf(\"%%s%s[%%d].\", prefix, i)", f.name))
+		g.printf("}\n")
+	case shapeMap:
+		switch {
+		case validate:
+			g.usesFmt, g.usesSorted = true, true
+			g.printf("for _, k := range slices.Sorted(maps.Keys(x.%s)) {\nv := x.%[1]s[k]\n", f.name)
+			callElem("v", fmt.Sprintf("fmt.Sprintf(\"%%s%s[%%v].\", prefix, k)", f.name))
+		case f.elemPtr:
+			g.printf("for _, v := range x.%s {\n", f.name)
+			callElem("v", "")
+		default:
+			// Map values are not addressable, so normalize a copy and store it back
+			g.printf("for k, v := range x.%s {\n", f.name)
+			call("v", "")
+			g.printf("x.%s[k] = v\n", f.name)
+		}
+		g.printf("}\n")
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+// parseSource parses one Go file for generate
+func parseSource(t *testing.T, name string, src []byte) []*ast.File {
+	t.Helper()
+	f, err := parser.ParseFile(token.NewFileSet(), name, src, parser.SkipObjectResolution)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []*ast.File{f}
+}
+
+// Test the generated code against the golden files in testdata
+func TestGenerate_Golden(t *testing.T) {
+	tests := []struct {
+		name  string
+		types []string
+	}{
+		{name: "shapes"},
+		{name: "nested", types: []string{"Base", "Branch", "Group"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := filepath.Join("testdata", tt.name+".input")
+			golden := filepath.Join("testdata", tt.name+".golden")
+
+			src, err := os.ReadFile(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := generate(parseSource(t, input, src), tt.types)
+			if err != nil {
+				t.Fatalf("generate() error = %v", err)
+			}
+
+			if *update {
+				if err := os.WriteFile(golden, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			expected, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, expected) {
+				t.Errorf("generated code differs from %s (run go test -update):\n%s", golden, got)
+			}
+		})
+	}
+}
+
+// Test that the committed example code is up to date
+func TestGenerate_Example(t *testing.T) {
+	dir := filepath.Join("internal", "example")
+	out := filepath.Join(dir, defaultOutput)
+
+	got, err := generateDir(dir, out, nil)
+	if err != nil {
+		t.Fatalf("generateDir() error = %v", err)
+	}
+	expected, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, expected) {
+		t.Errorf("%s is stale, run go generate ./...", out)
+	}
+}
+
+// Test the errors for invalid tags and unsupported types
+func TestGenerate_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		src           string
+		types         []string
+		expectedError string
+	}{
+		{
+			name:          "Unknown kind",
+			src:           "type T struct { Doc string `cpfcnpj:\"rg\"` }",
+			expectedError: "T.Doc: invalid cpfcnpj tag \"rg\": kind must be cpf, cnpj or any",
+		},
+		{
+			name:          "Unknown option",
+			src:           "type T struct { Doc string `cpfcnpj:\"cpf,normalize=upper\"` }",
+			expectedError: "T.Doc: invalid cpfcnpj tag \"cpf,normalize=upper\": unknown option \"normalize=upper\"",
+		},
+		{
+			name:          "Unsupported type",
+			src:           "type T struct { Doc []*string `cpfcnpj:\"cpf\"` }",
+			expectedError: "T.Doc: cpfcnpj tag on unsupported type []*string",
+		},
+		{
+			name:          "Unordered map key",
+			src:           "type T struct { Doc map[[2]int]string `cpfcnpj:\"cpf\"` }",
+			expectedError: "T.Doc: cpfcnpj tag on unsupported type map[[2]int]string",
+		},
+		{
+			name:          "No tagged structs",
+			src:           "type T struct { Name string }",
+			expectedError: "no structs with cpfcnpj tags in package p",
+		},
+		{
+			name:          "Unknown type",
+			src:           "type T struct { Doc string `cpfcnpj:\"cpf\"` }",
+			types:         []string{"U"},
+			expectedError: "type U not found or not a struct",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := generate(parseSource(t, "p.go", []byte("package p\n\n"+tt.src)), tt.types)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.expectedError) {
+				t.Errorf("generate() error = %v, want prefix %q", err, tt.expectedError)
+			}
+		})
+	}
+}
+
+// Test that run writes the output file
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	src, err := os.ReadFile(filepath.Join("testdata", "shapes.input"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "shapes.go"), src, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stderr bytes.Buffer
+	if code := run([]string{"-type", "Customer", "-output", "gen.go", dir}, &stderr); code != exitOK {
+		t.Fatalf("exit code = %d, want %d (stderr: %s)", code, exitOK, stderr.String())
+	}
+	got, err := os.ReadFile(filepath.Join(dir, "gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(got, []byte("func (x *Customer) Validate() error")) {
+		t.Errorf("output has no Customer.Validate:\n%s", got)
+	}
+
+	// A second run ignores its previous output
+	if code := run([]string{"-type", "Customer", "-output", "gen.go", dir}, &stderr); code != exitOK {
+		t.Errorf("second run exit code = %d, want %d (stderr: %s)", code, exitOK, stderr.String())
+	}
+
+	if code := run([]string{dir, "extra"}, &stderr); code != exitUsage {
+		t.Errorf("exit code with two directories = %d, want %d", code, exitUsage)
+	}
+	if code := run([]string{t.TempDir()}, &stderr); code != exitError {
+		t.Errorf("exit code for an empty directory = %d, want %d", code, exitError)
+	}
+}
@@ -0,0 +1,96 @@
+// Command cpfcnpj-server serves CPF and CNPJ validation over HTTP.
+//
+// Usage:
+//
+//	cpfcnpj-server [-addr :8080] [-max-batch 1000] [-workers 0]
+//
+// See package github.com/n0vdd/cpf_cnpj/httpapi for the routes and response format.
+// The server shuts down gracefully on SIGINT and SIGTERM.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/n0vdd/cpf_cnpj/httpapi"
+)
+
+// Server limits. Document inputs are capped at cpfcnpj.MaxInputSize by the handler, so
+// requests never need long headers or slow uploads.
+const (
+	readHeaderTimeout = 5 * time.Second
+	readTimeout       = 10 * time.Second
+	writeTimeout      = 30 * time.Second
+	idleTimeout       = 2 * time.Minute
+	maxHeaderBytes    = 16 << 10
+	shutdownTimeout   = 10 * time.Second
+)
+
+func main() {
+	server, err := newServer(os.Args[1:], os.Stderr)
+	if err != nil {
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := serve(ctx, server); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// newServer builds the HTTP server from the command line args, printing flag errors to stderr.
+func newServer(args []string, stderr io.Writer) (*http.Server, error) {
+	fs := flag.NewFlagSet("cpfcnpj-server", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addr := fs.String("addr", ":8080", "listen address")
+	maxBatch := fs.Int("max-batch", httpapi.DefaultMaxBatchSize, "maximum documents per batch request")
+	workers := fs.Int("workers", 0, "goroutines validating each batch (0 uses GOMAXPROCS)")
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 || *maxBatch <= 0 {
+		err := errors.New("takes no arguments and a positive -max-batch")
+		fmt.Fprintf(stderr, "cpfcnpj-server: %v\n", err) //nolint:errcheck // nowhere left to report the error
+		return nil, err
+	}
+
+	return &http.Server{
+		Addr:              *addr,
+		Handler:           httpapi.NewHandler(httpapi.Options{MaxBatchSize: *maxBatch, Workers: *workers}),
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+		WriteTimeout:      writeTimeout,
+		IdleTimeout:       idleTimeout,
+		MaxHeaderBytes:    maxHeaderBytes,
+	}, nil
+}
+
+// serve runs server until ctx is cancelled, then shuts it down gracefully.
+func serve(ctx context.Context, server *http.Server) error {
+	errc := make(chan error, 1)
+	go func() {
+		log.Printf("cpfcnpj-server listening on %s", server.Addr)
+		errc <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Test flag parsing and the configured server
+func TestNewServer(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		expectedAddr string
+		expectError  bool
+	}{
+		{name: "Defaults", args: nil, expectedAddr: ":8080"},
+		{name: "Custom address", args: []string{"-addr", "127.0.0.1:9000", "-max-batch", "10"},
+			expectedAddr: "127.0.0.1:9000"},
+		{name: "Unknown flag", args: []string{"-port", "80"}, expectError: true},
+		{name: "Zero batch size", args: []string{"-max-batch", "0"}, expectError: true},
+		{name: "Arguments", args: []string{"extra"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			server, err := newServer(tt.args, &stderr)
+			if tt.expectError {
+				if err == nil || stderr.Len() == 0 {
+					t.Errorf("newServer() error = %v, stderr %q, want an error reported on stderr", err, stderr.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newServer() error = %v", err)
+			}
+
+			if server.Addr != tt.expectedAddr || server.ReadHeaderTimeout == 0 || server.MaxHeaderBytes == 0 {
+				t.Errorf("server = {Addr: %q, ReadHeaderTimeout: %v, MaxHeaderBytes: %d}",
+					server.Addr, server.ReadHeaderTimeout, server.MaxHeaderBytes)
+			}
+
+			rec := httptest.NewRecorder()
+			server.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/validate/71656686759", nil))
+			if rec.Code != http.StatusOK {
+				t.Errorf("GET /v1/validate status = %d, want %d", rec.Code, http.StatusOK)
+			}
+		})
+	}
+}
+
+// Test that serve returns once the context is cancelled
+func TestServe_Shutdown(t *testing.T) {
+	server, err := newServer([]string{"-addr", "127.0.0.1:0"}, new(bytes.Buffer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := serve(ctx, server); err != nil {
+		t.Errorf("serve() error = %v, want nil after shutdown", err)
+	}
+}
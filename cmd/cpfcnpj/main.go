@@ -0,0 +1,461 @@
+// Command cpfcnpj validates, formats, cleans, generates and explains CPF and CNPJ documents.
+//
+// Usage:
+//
+//	cpfcnpj <command> [flags] [document ...]
+//
+// Commands read documents from the arguments or, when there are none, from standard input,
+// one per line. The exit status is 0 when every input is valid, 1 when any input is invalid
+// and 2 on usage errors. Pass --json for one JSON object per input instead of plain text.
+//
+// The csv command instead reads a CSV file from standard input, validates one column and writes
+// the file to standard output with that column normalized, printing a summary to standard error.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	cpfcnpj "github.com/n0vdd/cpf_cnpj"
+)
+
+// Exit codes
+const (
+	exitOK      = 0
+	exitInvalid = 1
+	exitUsage   = 2
+)
+
+const usage = `Usage: cpfcnpj <command> [flags] [document ...]
+
+Commands:
+  validate   report whether each document is valid
+  format     print each document formatted (XXX.XXX.XXX-XX, XX.XXX.XXX/XXXX-XX)
+  clean      print each document without formatting
+  generate   print random valid documents for testing
+  explain    describe each document and why it is invalid
+  csv        validate and normalize a document column of a CSV file
+
+Documents are read from the arguments, or from standard input one per line.
+The csv command reads a CSV file from standard input and writes it to standard output.
+Run "cpfcnpj <command> -h" for the flags of a command.
+`
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes the command line args and returns the exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		warnf(stderr, "%s", usage)
+		return exitUsage
+	}
+
+	cmd, args := args[0], args[1:]
+	switch cmd {
+	case "validate", "format", "clean", "explain":
+		return runCheck(cmd, args, stdin, stdout, stderr)
+	case "generate":
+		return runGenerate(args, stdout, stderr)
+	case "csv":
+		return runCSV(args, stdin, stdout, stderr)
+	case "help", "-h", "--help":
+		if _, err := io.WriteString(stdout, usage); err != nil {
+			return exitUsage
+		}
+		return exitOK
+	default:
+		warnf(stderr, "cpfcnpj: unknown command %q\n\n%s", cmd, usage)
+		return exitUsage
+	}
+}
+
+// report is the outcome for one input. It is printed as JSON with --json.
+type report struct {
+	Input     string            `json:"input"`
+	Valid     bool              `json:"valid"`
+	Kind      string            `json:"kind,omitempty"`
+	Raw       string            `json:"raw,omitempty"`
+	Formatted string            `json:"formatted,omitempty"`
+	Masked    string            `json:"masked,omitempty"`
+	Error     string            `json:"error,omitempty"`
+	Code      cpfcnpj.ErrorCode `json:"code,omitempty"`
+
+	// Set by explain
+	Position     *int     `json:"position,omitempty"`
+	Char         string   `json:"char,omitempty"`
+	Expected     string   `json:"expected,omitempty"`
+	Got          string   `json:"got,omitempty"`
+	CheckDigits  string   `json:"check_digits,omitempty"`
+	FiscalRegion *int     `json:"fiscal_region,omitempty"`
+	States       []string `json:"states,omitempty"`
+	Root         string   `json:"root,omitempty"`
+	Branch       string   `json:"branch,omitempty"`
+	Headquarters *bool    `json:"headquarters,omitempty"`
+}
+
+// runCheck runs the commands that process existing documents.
+func runCheck(cmd string, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet(cmd, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	kind := fs.String("kind", "any", "document type: cpf, cnpj or any")
+	asJSON := fs.Bool("json", false, "print one JSON object per input")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	docKind, err := kindFlag(*kind)
+	if err != nil {
+		warnf(stderr, "cpfcnpj %s: %v\n", cmd, err)
+		return exitUsage
+	}
+
+	code := exitOK
+	err = eachInput(fs.Args(), stdin, func(input string) error {
+		doc, err := cpfcnpj.ParseKind(docKind, input)
+		r := newReport(input, doc, err, cmd == "explain")
+		if !r.Valid {
+			code = exitInvalid
+		}
+
+		if *asJSON {
+			return json.NewEncoder(stdout).Encode(r)
+		}
+		return printReport(cmd, r, stdout, stderr)
+	})
+	if err != nil {
+		warnf(stderr, "cpfcnpj %s: %v\n", cmd, err)
+		return exitUsage
+	}
+
+	return code
+}
+
+// kindFlag converts a -kind value to the cpfcnpj.Kind selecting the validator.
+// KindUnknown means auto-detection.
+func kindFlag(kind string) (cpfcnpj.Kind, error) {
+	switch kind {
+	case "any":
+		return cpfcnpj.KindUnknown, nil
+	case "cpf":
+		return cpfcnpj.KindCPF, nil
+	case "cnpj":
+		return cpfcnpj.KindCNPJNumeric, nil
+	default:
+		return cpfcnpj.KindUnknown, fmt.Errorf("unknown kind %q, want cpf, cnpj or any", kind)
+	}
+}
+
+// eachInput calls fn for every argument, or for every non-blank line of stdin when there are no arguments.
+func eachInput(args []string, stdin io.Reader, fn func(string) error) error {
+	if len(args) > 0 {
+		for _, arg := range args {
+			if err := fn(arg); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(stdin)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// newReport describes the result of validating input. Detailed reports include the
+// document components and the position of the error.
+func newReport(input string, doc cpfcnpj.Document, err error, detailed bool) report {
+	r := report{Input: input}
+
+	if err != nil {
+		r.Error = err.Error()
+		r.Code = cpfcnpj.CodeOf(err)
+
+		var verr *cpfcnpj.ValidationError
+		if errors.As(err, &verr) {
+			r.Kind = verr.Kind.String()
+			if detailed {
+				if verr.Position >= 0 {
+					r.Position = &verr.Position
+					r.Char = string(verr.Char)
+				}
+				r.Expected = verr.Expected
+				r.Got = verr.Got
+			}
+		}
+		return r
+	}
+
+	r.Valid = true
+	r.Kind = doc.Kind().String()
+	r.Raw = doc.Raw()
+	r.Formatted = doc.String()
+	r.Masked = doc.Masked()
+	if !detailed {
+		return r
+	}
+
+	switch d := doc.(type) {
+	case cpfcnpj.CPF:
+		r.CheckDigits = d.Raw()[cpfcnpj.CPFBaseLength:]
+		region := d.FiscalRegion()
+		digit := region.Digit()
+		r.FiscalRegion = &digit
+		r.States = region.UFs()
+	case cpfcnpj.CNPJ:
+		r.CheckDigits = d.CheckDigits()
+		r.Root = d.Root().String()
+		r.Branch = d.Branch()
+		hq := d.IsHeadquarters()
+		r.Headquarters = &hq
+	}
+	return r
+}
+
+// printReport prints r as plain text for cmd.
+func printReport(cmd string, r report, stdout, stderr io.Writer) error {
+	var err error
+	switch cmd {
+	case "validate":
+		if r.Valid {
+			_, err = fmt.Fprintf(stdout, "%s\tvalid\t%s\n", r.Input, r.Kind)
+		} else {
+			_, err = fmt.Fprintf(stdout, "%s\tinvalid\t%s\t%s\n", r.Input, r.Code, r.Error)
+		}
+	case "format", "clean":
+		switch {
+		case !r.Valid:
+			_, err = fmt.Fprintf(stderr, "cpfcnpj %s: %s: %s\n", cmd, r.Input, r.Error)
+		case cmd == "format":
+			_, err = fmt.Fprintln(stdout, r.Formatted)
+		default:
+			_, err = fmt.Fprintln(stdout, r.Raw)
+		}
+	case "explain":
+		err = printExplanation(r, stdout)
+	}
+	return err
+}
+
+// printExplanation prints a detailed report as aligned "field: value" lines followed by a blank line.
+func printExplanation(r report, w io.Writer) error {
+	var b strings.Builder
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "%-14s %s\n", name+":", value)
+		}
+	}
+
+	field("input", r.Input)
+	field("kind", r.Kind)
+	field("valid", fmt.Sprint(r.Valid))
+	if r.Valid {
+		field("raw", r.Raw)
+		field("formatted", r.Formatted)
+		field("masked", r.Masked)
+		field("check digits", r.CheckDigits)
+		if r.FiscalRegion != nil {
+			field("fiscal region", fmt.Sprintf("%d (%s)", *r.FiscalRegion, strings.Join(r.States, ", ")))
+		}
+		field("root", r.Root)
+		field("branch", r.Branch)
+		if r.Headquarters != nil {
+			field("headquarters", fmt.Sprint(*r.Headquarters))
+		}
+	} else {
+		field("error", r.Error)
+		field("code", string(r.Code))
+		if r.Position != nil {
+			field("position", fmt.Sprintf("%d (%q)", *r.Position, r.Char))
+		}
+		field("expected", r.Expected)
+		field("got", r.Got)
+	}
+	b.WriteByte('\n')
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// runGenerate prints random valid documents.
+func runGenerate(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	kind := fs.String("kind", "cpf", "document type: cpf or cnpj")
+	count := fs.Int("n", 1, "number of documents")
+	alphanumeric := fs.Bool("alphanumeric", false, "generate alphanumeric CNPJs")
+	branch := fs.Int("branch", 1, "CNPJ branch order (1-9999)")
+	region := fs.Int("region", -1, "CPF fiscal region digit (0-9)")
+	seed := fs.Uint64("seed", 0, "seed for reproducible output (0 uses a random seed)")
+	raw := fs.Bool("raw", false, "print documents without formatting")
+	asJSON := fs.Bool("json", false, "print one JSON object per document")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() > 0 || *count < 0 {
+		warnf(stderr, "cpfcnpj generate: takes no arguments and a non-negative -n\n")
+		return exitUsage
+	}
+
+	generate, err := generator(*kind, *alphanumeric, *branch, *region, *seed)
+	if err != nil {
+		warnf(stderr, "cpfcnpj generate: %v\n", err)
+		return exitUsage
+	}
+
+	for range *count {
+		doc, err := generate()
+		if err != nil {
+			warnf(stderr, "cpfcnpj generate: %v\n", err)
+			return exitUsage
+		}
+
+		switch {
+		case *asJSON:
+			err = json.NewEncoder(stdout).Encode(newReport(doc.Raw(), doc, nil, false))
+		case *raw:
+			_, err = fmt.Fprintln(stdout, doc.Raw())
+		default:
+			_, err = fmt.Fprintln(stdout, doc.String())
+		}
+		if err != nil {
+			warnf(stderr, "cpfcnpj generate: %v\n", err)
+			return exitUsage
+		}
+	}
+
+	return exitOK
+}
+
+// generateFunc returns a new random document.
+type generateFunc func() (cpfcnpj.Document, error)
+
+// generator returns the generateFunc for kind, configured by the generate flags.
+func generator(kind string, alphanumeric bool, branch, region int, seed uint64) (generateFunc, error) {
+	opts := []cpfcnpj.GenerateOption{
+		cpfcnpj.WithBranch(branch),
+		cpfcnpj.WithFiscalRegion(cpfcnpj.FiscalRegion(region)),
+	}
+	if seed != 0 {
+		opts = append(opts, cpfcnpj.WithRand(rand.New(rand.NewPCG(seed, seed))))
+	}
+	if alphanumeric {
+		opts = append(opts, cpfcnpj.WithAlphanumeric())
+	}
+
+	switch kind {
+	case "cpf":
+		return func() (cpfcnpj.Document, error) { return cpfcnpj.GenerateCpf(opts...) }, nil
+	case "cnpj":
+		return func() (cpfcnpj.Document, error) { return cpfcnpj.GenerateCnpj(opts...) }, nil
+	default:
+		return nil, fmt.Errorf("unknown kind %q, want cpf or cnpj", kind)
+	}
+}
+
+// runCSV validates and normalizes the document column of a CSV file read from stdin.
+// The summary report is printed to stderr.
+func runCSV(args []string, stdin io.Reader, stdout, stderr io.Writer) (code int) {
+	fs := flag.NewFlagSet("csv", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	column := fs.String("column", "", "header name of the document column")
+	index := fs.Int("index", 0, "zero-based position of the document column, used when -column is empty")
+	kind := fs.String("kind", "any", "document type: cpf, cnpj or any")
+	format := fs.String("format", "keep", "rewrite valid documents as raw, formatted or keep them as they are")
+	status := fs.Bool("status", false, "append document_status and document_error_code columns")
+	rejects := fs.String("rejects", "", "write invalid rows to this file instead of standard output")
+	comma := fs.String("comma", ",", "field delimiter")
+	noHeader := fs.Bool("no-header", false, "the first row is data, not a header")
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	usageError := func(format string, args ...any) int {
+		warnf(stderr, "cpfcnpj csv: "+format+"\n", args...)
+		return exitUsage
+	}
+	if fs.NArg() > 0 {
+		return usageError("takes no arguments, pipe the CSV file to standard input")
+	}
+
+	opts, err := csvOptions(*kind, *format, *comma)
+	if err != nil {
+		return usageError("%v", err)
+	}
+	opts.Column, opts.ColumnIndex = *column, *index
+	opts.NoHeader, opts.AddStatus = *noHeader, *status
+
+	if *rejects != "" {
+		f, err := os.Create(*rejects)
+		if err != nil {
+			return usageError("%v", err)
+		}
+		defer func() {
+			if err := f.Close(); err != nil && code != exitUsage {
+				code = usageError("%v", err)
+			}
+		}()
+		opts.Rejects = f
+	}
+
+	report, err := cpfcnpj.NormalizeCSV(stdout, stdin, opts)
+	if err != nil {
+		return usageError("%v", err)
+	}
+
+	warnf(stderr, "cpfcnpj csv: %s\n", report)
+	if report.Invalid > 0 {
+		return exitInvalid
+	}
+	return exitOK
+}
+
+// csvOptions returns the NormalizeCSV options set by the -kind, -format and -comma flags.
+func csvOptions(kind, format, comma string) (cpfcnpj.CSVOptions, error) {
+	var opts cpfcnpj.CSVOptions
+
+	docKind, err := kindFlag(kind)
+	if err != nil {
+		return opts, err
+	}
+	opts.Kind = docKind
+
+	switch format {
+	case "keep":
+	case "raw":
+		opts.Format = cpfcnpj.FormatRaw
+	case "formatted":
+		opts.Format = cpfcnpj.FormatFormatted
+	default:
+		return opts, fmt.Errorf("unknown format %q, want raw, formatted or keep", format)
+	}
+
+	delimiter, size := utf8.DecodeRuneInString(comma)
+	if size == 0 || size != len(comma) {
+		return opts, fmt.Errorf("-comma must be a single character, got %q", comma)
+	}
+	opts.Comma = delimiter
+	return opts, nil
+}
+
+// warnf prints a diagnostic to stderr. A failed write to stderr has nowhere left to be reported.
+func warnf(stderr io.Writer, format string, args ...any) {
+	fmt.Fprintf(stderr, format, args...) //nolint:errcheck // nowhere left to report the error
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cpfcnpj "github.com/n0vdd/cpf_cnpj"
+)
+
+// Test the plain text output and exit codes of every command
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		stdin          string
+		expectedCode   int
+		expectedStdout string
+		expectedStderr string
+	}{
+		{
+			name:           "Validate valid arguments",
+			args:           []string{"validate", "716.566.867-59", "12ABC34501DE35"},
+			expectedCode:   exitOK,
+			expectedStdout: "716.566.867-59\tvalid\tcpf\n12ABC34501DE35\tvalid\tcnpj_alphanumeric\n",
+		},
+		{
+			name:         "Validate invalid argument",
+			args:         []string{"validate", "716.566.867-58"},
+			expectedCode: exitInvalid,
+			expectedStdout: "716.566.867-58\tinvalid\tchecksum\t" +
+				"CPF check digits are invalid: CPF checksum validation failed\n",
+		},
+		{
+			name:           "Validate from stdin skips blank lines",
+			args:           []string{"validate"},
+			stdin:          "71656686759\n\n  22796729000159  \n",
+			expectedCode:   exitOK,
+			expectedStdout: "71656686759\tvalid\tcpf\n22796729000159\tvalid\tcnpj_numeric\n",
+		},
+		{
+			name:         "Validate with kind",
+			args:         []string{"validate", "-kind", "cnpj", "71656686759"},
+			expectedCode: exitInvalid,
+			expectedStdout: "71656686759\tinvalid\tlength\t" +
+				"CNPJ must have exactly 14 characters, got 11: CNPJ must have exactly 14 characters\n",
+		},
+		{
+			name:           "Format",
+			args:           []string{"format", "71656686759", "12abc34501de35"},
+			expectedCode:   exitOK,
+			expectedStdout: "716.566.867-59\n12.ABC.345/01DE-35\n",
+		},
+		{
+			name:           "Format reports invalid input on stderr",
+			args:           []string{"format", "123", "22796729000159"},
+			expectedCode:   exitInvalid,
+			expectedStdout: "22.796.729/0001-59\n",
+			expectedStderr: "cpfcnpj format: 123: unable to determine document type from length 3: " +
+				"document must have 11 (CPF) or 14 (CNPJ) characters\n",
+		},
+		{
+			name:           "Clean",
+			args:           []string{"clean"},
+			stdin:          "716.566.867-59\n12.ABC.345/01DE-35\n",
+			expectedCode:   exitOK,
+			expectedStdout: "71656686759\n12ABC34501DE35\n",
+		},
+		{
+			name:         "Explain valid CPF",
+			args:         []string{"explain", "716.566.867-59"},
+			expectedCode: exitOK,
+			expectedStdout: "input:         716.566.867-59\n" +
+				"kind:          cpf\n" +
+				"valid:         true\n" +
+				"raw:           71656686759\n" +
+				"formatted:     716.566.867-59\n" +
+				"masked:        ***.566.867-**\n" +
+				"check digits:  59\n" +
+				"fiscal region: 7 (ES, RJ)\n\n",
+		},
+		{
+			name:         "Explain valid CNPJ",
+			args:         []string{"explain", "22796729000159"},
+			expectedCode: exitOK,
+			expectedStdout: "input:         22796729000159\n" +
+				"kind:          cnpj_numeric\n" +
+				"valid:         true\n" +
+				"raw:           22796729000159\n" +
+				"formatted:     22.796.729/0001-59\n" +
+				"masked:        22.796.729/****-**\n" +
+				"check digits:  59\n" +
+				"root:          22.796.729\n" +
+				"branch:        0001\n" +
+				"headquarters:  true\n\n",
+		},
+		{
+			name:         "Explain invalid check digits",
+			args:         []string{"explain", "12.ABC.345/01DE-99"},
+			expectedCode: exitInvalid,
+			expectedStdout: "input:         12.ABC.345/01DE-99\n" +
+				"kind:          cnpj_alphanumeric\n" +
+				"valid:         false\n" +
+				"error:         CNPJ check digits are invalid: CNPJ checksum validation failed\n" +
+				"code:          checksum\n" +
+				"position:      12 (\"9\")\n" +
+				"expected:      35\n" +
+				"got:           99\n\n",
+		},
+		{
+			name:         "CSV",
+			args:         []string{"csv", "-column", "doc", "-format", "formatted", "-status"},
+			stdin:        "doc,name\n71656686759,Ana\n22796729000159,Acme\n",
+			expectedCode: exitOK,
+			expectedStdout: "doc,name,document_status,document_error_code\n716.566.867-59,Ana,valid,\n" +
+				"22.796.729/0001-59,Acme,valid,\n",
+			expectedStderr: "cpfcnpj csv: 2 rows: 2 valid (cnpj_numeric: 1, cpf: 1), 0 invalid\n",
+		},
+		{
+			name:           "CSV with invalid rows",
+			args:           []string{"csv", "-no-header", "-index", "1", "-comma", ";", "-kind", "cpf", "-format", "raw"},
+			stdin:          "Ana;716.566.867-59\nBia;716.566.867-58\n",
+			expectedCode:   exitInvalid,
+			expectedStdout: "Ana;71656686759\nBia;716.566.867-58\n",
+			expectedStderr: "cpfcnpj csv: 2 rows: 1 valid (cpf: 1), 1 invalid (checksum: 1)\n",
+		},
+
+		// Usage errors
+		{name: "No command", args: nil, expectedCode: exitUsage},
+		{name: "Unknown command", args: []string{"frobnicate"}, expectedCode: exitUsage},
+		{name: "Unknown kind", args: []string{"validate", "-kind", "rg", "1"}, expectedCode: exitUsage},
+		{name: "Unknown flag", args: []string{"format", "-x"}, expectedCode: exitUsage},
+		{name: "Generate with arguments", args: []string{"generate", "extra"}, expectedCode: exitUsage},
+		{name: "Generate invalid region", args: []string{"generate", "-region", "12"}, expectedCode: exitUsage},
+		{name: "Generate unknown kind", args: []string{"generate", "-kind", "rg"}, expectedCode: exitUsage},
+		{name: "CSV unknown format", args: []string{"csv", "-format", "pretty"}, expectedCode: exitUsage},
+		{name: "CSV long delimiter", args: []string{"csv", "-comma", ";;"}, expectedCode: exitUsage},
+		{name: "CSV with arguments", args: []string{"csv", "file.csv"}, expectedCode: exitUsage},
+		{name: "CSV missing column", args: []string{"csv", "-column", "cpf"}, stdin: "doc\n1\n", expectedCode: exitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, strings.NewReader(tt.stdin), &stdout, &stderr)
+
+			if code != tt.expectedCode {
+				t.Errorf("exit code = %d, want %d (stderr: %s)", code, tt.expectedCode, stderr.String())
+			}
+			if tt.expectedStdout != "" || code != exitUsage {
+				if stdout.String() != tt.expectedStdout {
+					t.Errorf("stdout =\n%s\nwant\n%s", stdout.String(), tt.expectedStdout)
+				}
+			}
+			if tt.expectedStderr != "" && stderr.String() != tt.expectedStderr {
+				t.Errorf("stderr = %q, want %q", stderr.String(), tt.expectedStderr)
+			}
+			if code == exitUsage && stderr.Len() == 0 {
+				t.Error("usage error printed nothing to stderr")
+			}
+		})
+	}
+}
+
+// Test the JSON output
+func TestRun_JSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"explain", "--json", "716.566.867-59", "716.566.867-58"}, nil, &stdout, &stderr)
+	if code != exitInvalid {
+		t.Fatalf("exit code = %d, want %d", code, exitInvalid)
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d JSON lines, want 2:\n%s", len(lines), stdout.String())
+	}
+
+	var valid, invalid report
+	if err := json.Unmarshal([]byte(lines[0]), &valid); err != nil {
+		t.Fatalf("invalid JSON %q: %v", lines[0], err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &invalid); err != nil {
+		t.Fatalf("invalid JSON %q: %v", lines[1], err)
+	}
+
+	if !valid.Valid || valid.Kind != "cpf" || valid.Raw != "71656686759" || valid.FiscalRegion == nil ||
+		*valid.FiscalRegion != 7 {
+		t.Errorf("valid report = %+v", valid)
+	}
+	if invalid.Valid || invalid.Code != cpfcnpj.CodeInvalidChecksum || invalid.Position == nil ||
+		*invalid.Position != 10 || invalid.Expected != "59" || invalid.Got != "58" {
+		t.Errorf("invalid report = %+v", invalid)
+	}
+}
+
+// Test that generated documents are valid and honour the flags
+func TestRun_Generate(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"generate", "-kind", "cnpj", "-alphanumeric", "-branch", "12", "-n", "20", "-json"},
+		nil, &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("exit code = %d, want %d (stderr: %s)", code, exitOK, stderr.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 20 {
+		t.Fatalf("got %d documents, want 20", len(lines))
+	}
+	for _, line := range lines {
+		var r report
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("invalid JSON %q: %v", line, err)
+		}
+		cnpj, err := cpfcnpj.NewCnpj(r.Raw)
+		if err != nil || !r.Valid || r.Kind != "cnpj_alphanumeric" || cnpj.Branch() != "0012" {
+			t.Errorf("generated %+v, NewCnpj error %v", r, err)
+		}
+	}
+}
+
+// Test that a seed makes generate reproducible
+func TestRun_GenerateSeed(t *testing.T) {
+	generate := func() string {
+		var stdout, stderr bytes.Buffer
+		if code := run([]string{"generate", "-n", "5", "-seed", "7", "-region", "8", "-raw"}, nil, &stdout,
+			&stderr); code != exitOK {
+			t.Fatalf("exit code = %d, want %d (stderr: %s)", code, exitOK, stderr.String())
+		}
+		return stdout.String()
+	}
+
+	first := generate()
+	if second := generate(); first != second {
+		t.Errorf("seeded output differs:\n%s\n%s", first, second)
+	}
+	for _, line := range strings.Fields(first) {
+		cpf, err := cpfcnpj.NewCpf(line)
+		if err != nil || cpf.Raw() != line || cpf.FiscalRegion() != 8 {
+			t.Errorf("generated %q: region %v, error %v", line, cpf.FiscalRegion(), err)
+		}
+	}
+}
+
+// Test that csv writes invalid rows to the rejects file
+func TestRun_CSVRejects(t *testing.T) {
+	rejects := filepath.Join(t.TempDir(), "rejects.csv")
+	stdin := "doc,name\n716.566.867-59,Ana\n716.566.867-58,Bia\n"
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"csv", "-column", "doc", "-format", "raw", "-rejects", rejects}, strings.NewReader(stdin),
+		&stdout, &stderr)
+	if code != exitInvalid {
+		t.Fatalf("exit code = %d, want %d (stderr: %s)", code, exitInvalid, stderr.String())
+	}
+
+	if expected := "doc,name\n71656686759,Ana\n"; stdout.String() != expected {
+		t.Errorf("stdout = %q, want %q", stdout.String(), expected)
+	}
+	data, err := os.ReadFile(rejects)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "doc,name\n716.566.867-58,Bia\n"; string(data) != expected {
+		t.Errorf("rejects = %q, want %q", data, expected)
+	}
+}
@@ -1,14 +1,12 @@
 package cpfcnpj
 
-import (
-	"fmt"
-	"strconv"
-	"strings"
-)
+import "fmt"
 
 // Constants for CNPJ validation
 const (
-	CNPJLength = 14
+	CNPJLength     = 14
+	CNPJBaseLength = 12 // root and branch order, before the two check digits
+	CNPJRootLength = 8  // company identifier shared by all branches
 )
 
 // CNPJ validation tables for Module 11 algorithm
@@ -26,44 +24,147 @@ type CNPJ string
 // Supports both numeric and alphanumeric formats.
 // Returns error if CNPJ is invalid.
 func NewCnpj(s string) (CNPJ, error) {
+	// DoS protection: reject oversized inputs before cleaning
+	if len(s) > MaxInputSize {
+		return "", newValidationError(CodeInputTooLarge, KindCNPJNumeric, ErrInputTooLarge,
+			"CNPJ input has %d characters", len(s))
+	}
+
 	// Clean input: keep alphanumeric chars, normalize case
 	cleaned := Clean(s)
+	kind := cnpjKind(cleaned)
 
 	// Validate length
 	if len(cleaned) != CNPJLength {
-		return "", fmt.Errorf("CNPJ must have exactly %d characters, got %d: %w", CNPJLength, len(cleaned),
-			ErrCNPJInvalidLength)
+		return "", newValidationError(CodeInvalidLength, kind, ErrCNPJInvalidLength,
+			"CNPJ must have exactly %d characters, got %d", CNPJLength, len(cleaned))
 	}
 
 	// Validate character format
-	if !isValidCNPJFormat(cleaned) {
-		return "", fmt.Errorf("CNPJ format is invalid: first 12 characters must be A-Z or 0-9, "+
-			"last 2 must be 0-9: %w", ErrCNPJInvalidAlphanumeric)
+	if pos := invalidCNPJCharIndex(cleaned); pos != -1 {
+		return "", newCharsetError(kind, cleaned, pos, ErrCNPJInvalidAlphanumeric,
+			"CNPJ format is invalid: first 12 characters must be A-Z or 0-9, "+
+				"last 2 must be 0-9 (found %q at position %d)", cleaned[pos], pos)
 	}
 
 	// Reject invalid patterns (all same characters)
 	if isSameCharacter(cleaned) {
-		return "", fmt.Errorf("CNPJ cannot have all same characters: %w", ErrAllSameDigits)
+		return "", newValidationError(CodeSameDigits, kind, ErrAllSameDigits,
+			"CNPJ cannot have all same characters")
 	}
 
-	// Validate check digits using Module 11 algorithm
-	firstPart := cleaned[:12]
-	d1, d2, err := calculateModule11Digits(firstPart, cnpjFirstDigitTable, cnpjSecondDigitTable)
+	// Validate check digits using Module 11 algorithm, comparing digits in place
+	d1, d2, err := calculateModule11Digits(cleaned[:CNPJBaseLength], cnpjFirstDigitTable, cnpjSecondDigitTable)
 	if err != nil {
 		return "", fmt.Errorf("error calculating CNPJ check digits: %w", err)
 	}
 
-	expectedCNPJ := firstPart + strconv.Itoa(d1) + strconv.Itoa(d2)
-	if expectedCNPJ != cleaned {
-		return "", fmt.Errorf("CNPJ check digits are invalid: %w", ErrCNPJInvalidChecksum)
+	if pos := checkDigitMismatch(cleaned, d1, d2); pos != -1 {
+		verr := newChecksumError(kind, ErrCNPJInvalidChecksum, digitPair(d1, d2), cleaned[CNPJBaseLength:],
+			"CNPJ check digits are invalid")
+		verr.Position = pos
+		verr.Char = cleaned[pos]
+		return "", verr
 	}
 
 	return CNPJ(cleaned), nil
 }
 
+// IsValidCnpj reports whether NewCnpj would accept s. It does not allocate,
+// which makes it suitable for hot paths that only need a yes or no.
+func IsValidCnpj(s string) bool {
+	if len(s) > MaxInputSize {
+		return false
+	}
+
+	var buf [CNPJLength]byte
+	return isValidCnpjChars(cleanCnpjChars(buf[:], s))
+}
+
+// ParseCnpjBytes validates a CNPJ held in a byte slice, such as a field read by a CSV or
+// fixed-width reader, without converting it to a string first. It accepts the same input as
+// NewCnpj and returns the same errors; valid input allocates only the returned CNPJ.
+func ParseCnpjBytes(b []byte) (CNPJ, error) {
+	if len(b) > MaxInputSize {
+		return "", newValidationError(CodeInputTooLarge, KindCNPJNumeric, ErrInputTooLarge,
+			"CNPJ input has %d characters", len(b))
+	}
+
+	var buf [CNPJLength]byte
+	if cleaned := cleanCnpjChars(buf[:], b); isValidCnpjChars(cleaned) {
+		return CNPJ(cleaned), nil
+	}
+
+	// Slow path: let NewCnpj build the detailed error
+	return NewCnpj(string(b))
+}
+
+// cleanCnpjChars cleans s into buf the way Clean treats CNPJ input, without allocating.
+// It returns nil unless s has exactly CNPJLength alphanumeric characters.
+// buf must hold at least CNPJLength bytes.
+func cleanCnpjChars[T byteString](buf []byte, s T) []byte {
+	if cleanInto(buf[:CNPJLength], s) != CNPJLength {
+		return nil
+	}
+	return buf[:CNPJLength]
+}
+
+// isValidCnpjChars validates a cleaned CNPJ without allocating.
+func isValidCnpjChars[T byteString](s T) bool {
+	return len(s) == CNPJLength &&
+		invalidCNPJCharIndex(s) == -1 &&
+		!isSameCharacter(s) &&
+		hasValidCheckDigits(s, cnpjFirstDigitTable, cnpjSecondDigitTable)
+}
+
+// CnpjCheckDigits calculates the two Module 11 check digits for a 12-character CNPJ base
+// (root plus branch order). Both numeric and alphanumeric bases are supported, and the
+// base may be formatted or lowercase (e.g. "12.abc.345/01de").
+func CnpjCheckDigits(base string) (string, error) {
+	cleaned, err := cleanCnpjBase(base)
+	if err != nil {
+		return "", err
+	}
+
+	return checkDigits(cleaned, cnpjFirstDigitTable, cnpjSecondDigitTable)
+}
+
+// CompleteCnpj appends the check digits to a 12-character CNPJ base and returns the resulting CNPJ.
+// The result goes through NewCnpj, so bases that produce an invalid CNPJ are rejected.
+func CompleteCnpj(base string) (CNPJ, error) {
+	cleaned, err := cleanCnpjBase(base)
+	if err != nil {
+		return "", err
+	}
+
+	digits, err := checkDigits(cleaned, cnpjFirstDigitTable, cnpjSecondDigitTable)
+	if err != nil {
+		return "", err
+	}
+
+	return NewCnpj(cleaned + digits)
+}
+
+// cleanCnpjBase normalizes a CNPJ base and validates its length.
+// It uses cleanString instead of Clean so letters are kept for alphanumeric bases.
+func cleanCnpjBase(base string) (string, error) {
+	if len(base) > MaxInputSize {
+		return "", newValidationError(CodeInputTooLarge, KindCNPJNumeric, ErrInputTooLarge,
+			"CNPJ base input has %d characters", len(base))
+	}
+
+	cleaned := cleanString(base)
+	if len(cleaned) != CNPJBaseLength {
+		return "", newValidationError(CodeInvalidLength, cnpjKind(cleaned), ErrCNPJInvalidBaseLength,
+			"CNPJ base must have exactly %d characters, got %d", CNPJBaseLength, len(cleaned))
+	}
+
+	return cleaned, nil
+}
+
 // String returns the CNPJ formatted as XX.XXX.XXX/XXXX-XX.
-func (c *CNPJ) String() string {
-	str := string(*c)
+func (c CNPJ) String() string {
+	str := string(c)
 
 	// Safety check: only format if exactly 14 characters
 	if len(str) != CNPJLength {
@@ -74,13 +175,102 @@ func (c *CNPJ) String() string {
 	return formatDocument(str, "XX.XXX.XXX/XXXX-XX")
 }
 
+// AppendFormatted appends the CNPJ formatted as XX.XXX.XXX/XXXX-XX to dst and returns the extended
+// buffer, like the strconv.Append functions. Values of the wrong length are appended as-is.
+func (c CNPJ) AppendFormatted(dst []byte) []byte {
+	if len(c) != CNPJLength {
+		return append(dst, c...)
+	}
+	return appendDocument(dst, string(c), "XX.XXX.XXX/XXXX-XX")
+}
+
+// AppendRaw appends the unformatted CNPJ to dst and returns the extended buffer.
+func (c CNPJ) AppendRaw(dst []byte) []byte {
+	return append(dst, c...)
+}
+
 // Raw returns the CNPJ as unformatted string (digits and letters only).
 // This is a zero-allocation method that returns the underlying
 // clean CNPJ characters without any formatting symbols.
 // Supports both numeric (e.g., "22796729000159") and
 // alphanumeric (e.g., "12ABC34501DE35") formats.
-func (c *CNPJ) Raw() string {
-	return string(*c)
+func (c CNPJ) Raw() string {
+	return string(c)
+}
+
+// Kind returns KindCNPJAlphanumeric when any of the first 12 characters is a letter,
+// and KindCNPJNumeric otherwise.
+func (c CNPJ) Kind() Kind {
+	return cnpjKind(string(c))
+}
+
+// Masked returns the CNPJ with only the root (company identifier) visible,
+// hiding the branch order and check digits (e.g. "22.796.729/****-**").
+func (c CNPJ) Masked() string {
+	return c.MaskedWith(MaskRootVisible)
+}
+
+// MaskedWith returns the formatted CNPJ with the characters hidden by style replaced by '*'.
+func (c CNPJ) MaskedWith(style MaskStyle) string {
+	str := string(c)
+
+	// Never expose a value that is not a well-formed CNPJ
+	if len(str) != CNPJLength {
+		return maskAll(str)
+	}
+
+	return formatDocument(str, style.pattern(c.Kind(), "XX.XXX.XXX/XXXX-XX"))
+}
+
+// MatchesMasked reports whether a masked value such as "22.796.729/****-**", read back by
+// a customer, is consistent with this CNPJ. Formatting is ignored, letters are compared
+// case-insensitively and '*' matches any character. A value with no visible characters never matches.
+func (c CNPJ) MatchesMasked(masked string) bool {
+	return len(c) == CNPJLength && matchesMasked(string(c), masked)
+}
+
+// Root returns the first 8 characters, which identify the company across all of its branches.
+// Returns an empty root if the CNPJ is not 14 characters long.
+func (c CNPJ) Root() CNPJRoot {
+	if len(c) != CNPJLength {
+		return ""
+	}
+	return CNPJRoot(c[:CNPJRootLength])
+}
+
+// Branch returns the branch order ("ordem"), positions 9 to 12 (e.g. "0001").
+// Returns an empty string if the CNPJ is not 14 characters long.
+func (c CNPJ) Branch() string {
+	if len(c) != CNPJLength {
+		return ""
+	}
+	return string(c[CNPJRootLength:CNPJBaseLength])
+}
+
+// CheckDigits returns the two trailing Module 11 check digits.
+// Returns an empty string if the CNPJ is not 14 characters long.
+func (c CNPJ) CheckDigits() string {
+	if len(c) != CNPJLength {
+		return ""
+	}
+	return string(c[CNPJBaseLength:])
+}
+
+// IsHeadquarters reports whether the CNPJ belongs to the headquarters (matriz), i.e. branch "0001".
+func (c CNPJ) IsHeadquarters() bool {
+	return c.Branch() == "0001"
+}
+
+// Headquarters returns the headquarters (matriz) CNPJ of the same company:
+// the same root with branch "0001" and recomputed check digits.
+// Works for both numeric and alphanumeric roots.
+func (c CNPJ) Headquarters() (CNPJ, error) {
+	return c.Root().Headquarters()
+}
+
+// IsAlphanumeric reports whether the CNPJ uses the CNPJ Alfanumérico format.
+func (c CNPJ) IsAlphanumeric() bool {
+	return c.Kind() == KindCNPJAlphanumeric
 }
 
 // isValidCNPJFormat validates the character format of CNPJ
@@ -88,22 +278,28 @@ func isValidCNPJFormat(cnpj string) bool {
 	if len(cnpj) != CNPJLength {
 		return false
 	}
+	return invalidCNPJCharIndex(cnpj) == -1
+}
 
-	// First 12 characters must be alphanumeric (A-Z, 0-9)
-	firstTwelve := cnpj[:12]
-	if invalidIndex := strings.IndexFunc(firstTwelve, func(r rune) bool {
-		return !((r >= '0' && r <= '9') || (r >= 'A' && r <= 'Z'))
-	}); invalidIndex != -1 {
-		return false
+// invalidCNPJCharIndex returns the position of the first character that is not allowed
+// at its position in a 14-character CNPJ, or -1 if every character is valid.
+func invalidCNPJCharIndex[T byteString](cnpj T) int {
+	for i := 0; i < len(cnpj); i++ {
+		ch := cnpj[i]
+		// First 12 characters must be alphanumeric (A-Z, 0-9), last 2 numeric (check digits)
+		if ch >= '0' && ch <= '9' || (i < CNPJBaseLength && ch >= 'A' && ch <= 'Z') {
+			continue
+		}
+		return i
 	}
 
-	// Last 2 characters must be numeric (check digits)
-	lastTwo := cnpj[12:]
-	if invalidIndex := strings.IndexFunc(lastTwo, func(r rune) bool {
-		return !(r >= '0' && r <= '9')
-	}); invalidIndex != -1 {
-		return false
-	}
+	return -1
+}
 
-	return true
+// cnpjKind classifies a cleaned CNPJ candidate as numeric or alphanumeric.
+func cnpjKind(s string) Kind {
+	if hasLetter(s) {
+		return KindCNPJAlphanumeric
+	}
+	return KindCNPJNumeric
 }
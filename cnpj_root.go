@@ -0,0 +1,106 @@
+package cpfcnpj
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+// CNPJ root errors
+var (
+	ErrCNPJInvalidRoot   = errors.New("CNPJ root must have exactly 8 characters A-Z or 0-9")
+	ErrCNPJInvalidBranch = errors.New("CNPJ branch order must be between 1 and 9999")
+)
+
+// Branch order limits
+const (
+	// headquartersBranch is the branch order ("ordem") of a company's headquarters (matriz).
+	headquartersBranch = 1
+	// maxBranch is the highest numeric branch order that fits in the 4 CNPJ order positions.
+	maxBranch = 9999
+)
+
+// CNPJRoot is the 8-character company identifier ("raiz") shared by every branch of a CNPJ.
+// Numeric roots use only digits; CNPJ Alfanumérico roots may also contain uppercase letters.
+type CNPJRoot string
+
+// NewCnpjRoot creates and validates a CNPJ root from a string.
+// Formatting characters are removed and lowercase letters are normalized, so
+// "12.abc.345" and "12ABC345" produce the same root.
+func NewCnpjRoot(s string) (CNPJRoot, error) {
+	if len(s) > MaxInputSize {
+		return "", newValidationError(CodeInputTooLarge, KindCNPJNumeric, ErrInputTooLarge,
+			"CNPJ root input has %d characters", len(s))
+	}
+
+	// cleanString keeps only A-Z and 0-9, so length is the only remaining check
+	cleaned := cleanString(s)
+	if len(cleaned) != CNPJRootLength {
+		return "", newValidationError(CodeInvalidLength, cnpjKind(cleaned), ErrCNPJInvalidRoot,
+			"CNPJ root must have exactly %d characters, got %d", CNPJRootLength, len(cleaned))
+	}
+
+	return CNPJRoot(cleaned), nil
+}
+
+// String returns the root formatted as XX.XXX.XXX.
+func (r CNPJRoot) String() string {
+	str := string(r)
+
+	// Safety check: only format if exactly 8 characters
+	if len(str) != CNPJRootLength {
+		return str
+	}
+
+	return formatDocument(str, "XX.XXX.XXX")
+}
+
+// Raw returns the root without formatting characters.
+func (r CNPJRoot) Raw() string {
+	return string(r)
+}
+
+// IsAlphanumeric reports whether the root contains letters (CNPJ Alfanumérico).
+func (r CNPJRoot) IsAlphanumeric() bool {
+	return hasLetter(string(r))
+}
+
+// Branch builds the CNPJ of branch number order (1-9999) for this root,
+// computing its check digits. Branch(1) is the headquarters.
+func (r CNPJRoot) Branch(order int) (CNPJ, error) {
+	if len(r) != CNPJRootLength {
+		return "", fmt.Errorf("CNPJ root %q has %d characters: %w", string(r), len(r), ErrCNPJInvalidRoot)
+	}
+	if order < headquartersBranch || order > maxBranch {
+		return "", fmt.Errorf("branch order %d out of range: %w", order, ErrCNPJInvalidBranch)
+	}
+
+	return CompleteCnpj(fmt.Sprintf("%s%04d", string(r), order))
+}
+
+// Headquarters builds the headquarters (matriz) CNPJ for this root: root + "0001" + check digits.
+func (r CNPJRoot) Headquarters() (CNPJ, error) {
+	return r.Branch(headquartersBranch)
+}
+
+// Branches returns an iterator over the CNPJs of branches 0001 through 9999 of this root, in order.
+// Nothing is yielded if the root is invalid.
+//
+//	for cnpj := range root.Branches() {
+//	    if found(cnpj) {
+//	        break
+//	    }
+//	}
+func (r CNPJRoot) Branches() iter.Seq[CNPJ] {
+	return func(yield func(CNPJ) bool) {
+		for order := headquartersBranch; order <= maxBranch; order++ {
+			cnpj, err := r.Branch(order)
+			if err != nil {
+				return
+			}
+			if !yield(cnpj) {
+				return
+			}
+		}
+	}
+}
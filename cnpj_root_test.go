@@ -0,0 +1,191 @@
+package cpfcnpj
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Test NewCnpjRoot constructor
+func TestNewCnpjRoot(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		expectedRoot CNPJRoot
+		expectedErr  error
+	}{
+		// Valid roots
+		{"Numeric clean", "22796729", "22796729", nil},
+		{"Numeric formatted", "22.796.729", "22796729", nil},
+		{"Alphanumeric clean", "12ABC345", "12ABC345", nil},
+		{"Alphanumeric formatted lowercase", "12.abc.345", "12ABC345", nil},
+		{"All zeros is a valid root", "00000000", "00000000", nil},
+
+		// Invalid roots
+		{"Empty", "", "", ErrCNPJInvalidRoot},
+		{"Too short", "1234567", "", ErrCNPJInvalidRoot},
+		{"Too long", "123456789", "", ErrCNPJInvalidRoot},
+		{"Full CNPJ", "22796729000159", "", ErrCNPJInvalidRoot},
+		{"Symbols only count as formatting", "12@ABC#34", "", ErrCNPJInvalidRoot},
+		{"Oversized input", strings.Repeat("A", MaxInputSize+1), "", ErrInputTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := NewCnpjRoot(tt.input)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("NewCnpjRoot(%q) error = %v, want %v", tt.input, err, tt.expectedErr)
+			}
+			if root != tt.expectedRoot {
+				t.Errorf("NewCnpjRoot(%q) = %q, want %q", tt.input, root, tt.expectedRoot)
+			}
+		})
+	}
+}
+
+// Test CNPJRoot formatting and helpers
+func TestCNPJRootMethods(t *testing.T) {
+	tests := []struct {
+		name                 string
+		root                 CNPJRoot
+		expectedString       string
+		expectedAlphanumeric bool
+	}{
+		{"Numeric root", "22796729", "22.796.729", false},
+		{"Alphanumeric root", "12ABC345", "12.ABC.345", true},
+		{"Wrong length returned as-is", "123", "123", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.root.String(); got != tt.expectedString {
+				t.Errorf("CNPJRoot(%q).String() = %q, want %q", tt.root, got, tt.expectedString)
+			}
+			if got := tt.root.Raw(); got != string(tt.root) {
+				t.Errorf("CNPJRoot(%q).Raw() = %q, want %q", tt.root, got, string(tt.root))
+			}
+			if got := tt.root.IsAlphanumeric(); got != tt.expectedAlphanumeric {
+				t.Errorf("CNPJRoot(%q).IsAlphanumeric() = %v, want %v", tt.root, got, tt.expectedAlphanumeric)
+			}
+		})
+	}
+}
+
+// Test building branch CNPJs from a root
+func TestCNPJRootBranch(t *testing.T) {
+	tests := []struct {
+		name         string
+		root         CNPJRoot
+		order        int
+		expectedCNPJ CNPJ
+		expectedErr  error
+	}{
+		{"Numeric headquarters", "22796729", 1, "22796729000159", nil},
+		{"Numeric second branch", "11222333", 2, "11222333000262", nil},
+		{"Highest branch", "11222333", 9999, "", nil},
+		{"Alphanumeric headquarters", "12ABC345", 1, "", nil},
+
+		// Errors
+		{"Branch zero", "22796729", 0, "", ErrCNPJInvalidBranch},
+		{"Branch too high", "22796729", 10000, "", ErrCNPJInvalidBranch},
+		{"Invalid root", "123", 1, "", ErrCNPJInvalidRoot},
+		{"Zero value root", "", 1, "", ErrCNPJInvalidRoot},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cnpj, err := tt.root.Branch(tt.order)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("CNPJRoot(%q).Branch(%d) error = %v, want %v", tt.root, tt.order, err, tt.expectedErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if tt.expectedCNPJ != "" && cnpj != tt.expectedCNPJ {
+				t.Errorf("CNPJRoot(%q).Branch(%d) = %q, want %q", tt.root, tt.order, cnpj, tt.expectedCNPJ)
+			}
+			if _, err := NewCnpj(cnpj.Raw()); err != nil {
+				t.Errorf("CNPJRoot(%q).Branch(%d) = %q does not pass NewCnpj: %v", tt.root, tt.order, cnpj, err)
+			}
+			if cnpj.Root() != tt.root {
+				t.Errorf("CNPJRoot(%q).Branch(%d).Root() = %q", tt.root, tt.order, cnpj.Root())
+			}
+		})
+	}
+}
+
+// Test deriving the headquarters CNPJ from any branch
+func TestCNPJHeadquarters(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected CNPJ
+	}{
+		{"Numeric branch to headquarters", "11.222.333/0002-62", "11222333000181"},
+		{"Headquarters maps to itself", "22.796.729/0001-59", "22796729000159"},
+		{"Alphanumeric branch to headquarters", "12.ABC.345/01DE-35", "12ABC345000188"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cnpj, err := NewCnpj(tt.input)
+			if err != nil {
+				t.Fatalf("NewCnpj(%q) unexpected error: %v", tt.input, err)
+			}
+
+			hq, err := cnpj.Headquarters()
+			if err != nil {
+				t.Fatalf("Headquarters() unexpected error: %v", err)
+			}
+			if hq != tt.expected {
+				t.Errorf("Headquarters() = %q, want %q", hq, tt.expected)
+			}
+			if !hq.IsHeadquarters() {
+				t.Errorf("Headquarters() = %q is not a headquarters CNPJ", hq)
+			}
+		})
+	}
+
+	if _, err := CNPJ("123").Headquarters(); !errors.Is(err, ErrCNPJInvalidRoot) {
+		t.Errorf("CNPJ(\"123\").Headquarters() error = %v, want %v", err, ErrCNPJInvalidRoot)
+	}
+}
+
+// Test iterating branches of a root
+func TestCNPJRootBranches(t *testing.T) {
+	root := CNPJRoot("12ABC345")
+
+	var got []CNPJ
+	for cnpj := range root.Branches() {
+		got = append(got, cnpj)
+		if len(got) == 3 {
+			break
+		}
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("Branches() yielded %d CNPJs before break, want 3", len(got))
+	}
+	for i, cnpj := range got {
+		expected, err := root.Branch(i + 1)
+		if err != nil {
+			t.Fatalf("Branch(%d) unexpected error: %v", i+1, err)
+		}
+		if cnpj != expected {
+			t.Errorf("Branches()[%d] = %q, want %q", i, cnpj, expected)
+		}
+	}
+
+	count := 0
+	for range CNPJRoot("22796729").Branches() {
+		count++
+	}
+	if count != maxBranch {
+		t.Errorf("Branches() yielded %d CNPJs, want %d", count, maxBranch)
+	}
+
+	for cnpj := range CNPJRoot("bad").Branches() {
+		t.Errorf("Branches() on invalid root yielded %q", cnpj)
+	}
+}
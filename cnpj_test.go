@@ -527,3 +527,145 @@ func BenchmarkCNPJRaw(b *testing.B) {
 		_ = cnpj.Raw()
 	}
 }
+
+// Test CNPJ check digit calculation and completion from a 12-character base
+func TestCompleteCnpj(t *testing.T) {
+	tests := []struct {
+		name           string
+		base           string
+		expectedDigits string
+		expectedCNPJ   string
+		expectedErr    error
+	}{
+		{"Numeric clean base", "227967290001", "59", "22796729000159", nil},
+		{"Numeric formatted base", "22.796.729/0001", "59", "22796729000159", nil},
+		{"Alphanumeric clean base", "12ABC34501DE", "35", "12ABC34501DE35", nil},
+		{"Alphanumeric formatted base", "12.ABC.345/01DE", "35", "12ABC34501DE35", nil},
+		{"Alphanumeric lowercase base", "12.abc.345/01de", "35", "12ABC34501DE35", nil},
+		{"Partner root plus branch", "11222333" + "0001", "81", "11222333000181", nil},
+
+		// Errors
+		{"Empty base", "", "", "", ErrCNPJInvalidBaseLength},
+		{"Too short", "12ABC34501D", "", "", ErrCNPJInvalidBaseLength},
+		{"Full CNPJ instead of base", "22796729000159", "", "", ErrCNPJInvalidBaseLength},
+		{"Oversized input", strings.Repeat("A", MaxInputSize+1), "", "", ErrInputTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			digits, err := CnpjCheckDigits(tt.base)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("CnpjCheckDigits(%q) error = %v, want %v", tt.base, err, tt.expectedErr)
+			}
+			if digits != tt.expectedDigits {
+				t.Errorf("CnpjCheckDigits(%q) = %q, want %q", tt.base, digits, tt.expectedDigits)
+			}
+
+			cnpj, err := CompleteCnpj(tt.base)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("CompleteCnpj(%q) error = %v, want %v", tt.base, err, tt.expectedErr)
+			}
+			if cnpj.Raw() != tt.expectedCNPJ {
+				t.Errorf("CompleteCnpj(%q) = %q, want %q", tt.base, cnpj.Raw(), tt.expectedCNPJ)
+			}
+		})
+	}
+}
+
+// Test that CompleteCnpj error codes identify base length failures
+func TestCompleteCnpj_ErrorCode(t *testing.T) {
+	_, err := CompleteCnpj("12ABC")
+	if code := CodeOf(err); code != CodeInvalidLength {
+		t.Errorf("CompleteCnpj(short base) code = %q, want %q", code, CodeInvalidLength)
+	}
+}
+
+// Test CNPJ component accessors
+func TestCNPJComponents(t *testing.T) {
+	tests := []struct {
+		name                 string
+		cnpj                 CNPJ
+		expectedRoot         CNPJRoot
+		expectedBranch       string
+		expectedCheckDigits  string
+		expectedHeadquarters bool
+		expectedAlphanumeric bool
+	}{
+		{"Numeric headquarters", "22796729000159", "22796729", "0001", "59", true, false},
+		{"Alphanumeric branch", "12ABC34501DE35", "12ABC345", "01DE", "35", false, true},
+		{"Numeric branch", "11222333000262", "11222333", "0002", "62", false, false},
+		{"Wrong length", "123", "", "", "", false, false},
+		{"Zero value", "", "", "", "", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.cnpj.Root(); got != tt.expectedRoot {
+				t.Errorf("CNPJ(%q).Root() = %q, want %q", tt.cnpj, got, tt.expectedRoot)
+			}
+			if got := tt.cnpj.Branch(); got != tt.expectedBranch {
+				t.Errorf("CNPJ(%q).Branch() = %q, want %q", tt.cnpj, got, tt.expectedBranch)
+			}
+			if got := tt.cnpj.CheckDigits(); got != tt.expectedCheckDigits {
+				t.Errorf("CNPJ(%q).CheckDigits() = %q, want %q", tt.cnpj, got, tt.expectedCheckDigits)
+			}
+			if got := tt.cnpj.IsHeadquarters(); got != tt.expectedHeadquarters {
+				t.Errorf("CNPJ(%q).IsHeadquarters() = %v, want %v", tt.cnpj, got, tt.expectedHeadquarters)
+			}
+			if got := tt.cnpj.IsAlphanumeric(); got != tt.expectedAlphanumeric {
+				t.Errorf("CNPJ(%q).IsAlphanumeric() = %v, want %v", tt.cnpj, got, tt.expectedAlphanumeric)
+			}
+		})
+	}
+}
+
+// Test that ParseCnpjBytes agrees with NewCnpj
+func TestParseCnpjBytes(t *testing.T) {
+	inputs := []string{
+		"22.796.729/0001-59", "22796729000159", "12.ABC.345/01DE-35", "12.abc.345/01de-35",
+		"", "123", "22796729000158", "00000000000000", "12ABC34501DEAB", "12ABC34501DE355",
+		strings.Repeat("A", MaxInputSize+1),
+	}
+
+	for _, input := range inputs {
+		t.Run(input[:min(len(input), 20)], func(t *testing.T) {
+			want, wantErr := NewCnpj(input)
+			got, err := ParseCnpjBytes([]byte(input))
+
+			if got != want {
+				t.Errorf("ParseCnpjBytes(%q) = %q, want %q", input, got, want)
+			}
+			if (err == nil) != (wantErr == nil) || (err != nil && err.Error() != wantErr.Error()) {
+				t.Errorf("ParseCnpjBytes(%q) error = %v, want %v", input, err, wantErr)
+			}
+			if CodeOf(err) != CodeOf(wantErr) {
+				t.Errorf("ParseCnpjBytes(%q) code = %q, want %q", input, CodeOf(err), CodeOf(wantErr))
+			}
+		})
+	}
+}
+
+// Test appending CNPJs to byte slices
+func TestCNPJAppend(t *testing.T) {
+	tests := []struct {
+		name              string
+		cnpj              CNPJ
+		expectedFormatted string
+		expectedRaw       string
+	}{
+		{"Numeric CNPJ", "22796729000159", "22.796.729/0001-59;", "22796729000159;"},
+		{"Alphanumeric CNPJ", "12ABC34501DE35", "12.ABC.345/01DE-35;", "12ABC34501DE35;"},
+		{"Wrong length CNPJ", "12345", "12345;", "12345;"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(append(tt.cnpj.AppendFormatted(nil), ';')); got != tt.expectedFormatted {
+				t.Errorf("AppendFormatted() = %q, want %q", got, tt.expectedFormatted)
+			}
+			if got := string(append(tt.cnpj.AppendRaw(nil), ';')); got != tt.expectedRaw {
+				t.Errorf("AppendRaw() = %q, want %q", got, tt.expectedRaw)
+			}
+		})
+	}
+}
@@ -11,14 +11,12 @@
 // The package supports both numeric and alphanumeric CNPJ formats.
 package cpfcnpj
 
-import (
-	"fmt"
-	"strconv"
-)
+import "fmt"
 
 // Constants for CPF validation
 const (
-	CPFLength = 11
+	CPFLength     = 11
+	CPFBaseLength = 9 // digits before the two check digits
 )
 
 // CPF validation tables for Module 11 algorithm
@@ -33,38 +31,150 @@ type CPF string
 // NewCpf creates and validates a CPF from a string.
 // Returns error if CPF is invalid.
 func NewCpf(s string) (CPF, error) {
+	// DoS protection: reject oversized inputs before cleaning
+	if len(s) > MaxInputSize {
+		return "", newValidationError(CodeInputTooLarge, KindCPF, ErrInputTooLarge,
+			"CPF input has %d characters", len(s))
+	}
+
 	// Clean input: keep only digits
 	cleaned := Clean(s)
 
 	// Validate length
 	if len(cleaned) != CPFLength {
-		return "", fmt.Errorf("CPF must have exactly %d digits, got %d: %w", CPFLength, len(cleaned),
-			ErrCPFInvalidLength)
+		return "", newValidationError(CodeInvalidLength, KindCPF, ErrCPFInvalidLength,
+			"CPF must have exactly %d digits, got %d", CPFLength, len(cleaned))
 	}
 
 	// Reject invalid patterns (all same digits)
 	if isSameCharacter(cleaned) {
-		return "", fmt.Errorf("CPF cannot have all digits the same: %w", ErrAllSameDigits)
+		return "", newValidationError(CodeSameDigits, KindCPF, ErrAllSameDigits,
+			"CPF cannot have all digits the same")
 	}
 
-	// Validate check digits using Module 11 algorithm
-	firstPart := cleaned[:9]
-	d1, d2, err := calculateModule11Digits(firstPart, cpfFirstDigitTable, cpfSecondDigitTable)
+	// Validate check digits using Module 11 algorithm, comparing digits in place
+	d1, d2, err := calculateModule11Digits(cleaned[:CPFBaseLength], cpfFirstDigitTable, cpfSecondDigitTable)
 	if err != nil {
 		return "", fmt.Errorf("error calculating CPF check digits: %w", err)
 	}
 
-	expectedCPF := firstPart + strconv.Itoa(d1) + strconv.Itoa(d2)
-	if expectedCPF != cleaned {
-		return "", fmt.Errorf("CPF check digits are invalid: %w", ErrCPFInvalidChecksum)
+	if pos := checkDigitMismatch(cleaned, d1, d2); pos != -1 {
+		verr := newChecksumError(KindCPF, ErrCPFInvalidChecksum, digitPair(d1, d2), cleaned[CPFBaseLength:],
+			"CPF check digits are invalid")
+		verr.Position = pos
+		verr.Char = cleaned[pos]
+		return "", verr
 	}
 
 	return CPF(cleaned), nil
 }
 
+// IsValidCpf reports whether NewCpf would accept s. It does not allocate,
+// which makes it suitable for hot paths that only need a yes or no.
+func IsValidCpf(s string) bool {
+	if len(s) > MaxInputSize {
+		return false
+	}
+
+	var buf [CNPJLength]byte
+	return isValidCpfChars(cleanCpfChars(buf[:], s))
+}
+
+// ParseCpfBytes validates a CPF held in a byte slice, such as a field read by a CSV or
+// fixed-width reader, without converting it to a string first. It accepts the same input as
+// NewCpf and returns the same errors; valid input allocates only the returned CPF.
+func ParseCpfBytes(b []byte) (CPF, error) {
+	if len(b) > MaxInputSize {
+		return "", newValidationError(CodeInputTooLarge, KindCPF, ErrInputTooLarge,
+			"CPF input has %d characters", len(b))
+	}
+
+	var buf [CNPJLength]byte
+	if cleaned := cleanCpfChars(buf[:], b); isValidCpfChars(cleaned) {
+		return CPF(cleaned), nil
+	}
+
+	// Slow path: let NewCpf build the detailed error
+	return NewCpf(string(b))
+}
+
+// cleanCpfChars cleans s into buf the way Clean treats CPF input, without allocating:
+// letters are dropped unless there are enough characters for a CNPJ, in which case nil is returned.
+// buf must hold at least CNPJLength bytes.
+func cleanCpfChars[T byteString](buf []byte, s T) []byte {
+	n := cleanInto(buf[:CNPJLength], s)
+	if n >= CNPJLength {
+		return nil
+	}
+
+	digits := buf[:0]
+	for _, ch := range buf[:n] {
+		if ch >= '0' && ch <= '9' {
+			digits = append(digits, ch)
+		}
+	}
+	return digits
+}
+
+// isValidCpfChars validates a cleaned CPF without allocating.
+func isValidCpfChars[T byteString](s T) bool {
+	if len(s) != CPFLength || isSameCharacter(s) {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return hasValidCheckDigits(s, cpfFirstDigitTable, cpfSecondDigitTable)
+}
+
+// CpfCheckDigits calculates the two Module 11 check digits for a 9-digit CPF base.
+// The base may be formatted (e.g. "716.566.867").
+func CpfCheckDigits(base string) (string, error) {
+	cleaned, err := cleanCpfBase(base)
+	if err != nil {
+		return "", err
+	}
+
+	return checkDigits(cleaned, cpfFirstDigitTable, cpfSecondDigitTable)
+}
+
+// CompleteCpf appends the check digits to a 9-digit CPF base and returns the resulting CPF.
+// The result goes through NewCpf, so bases that produce an invalid CPF (e.g. all same digits) are rejected.
+func CompleteCpf(base string) (CPF, error) {
+	cleaned, err := cleanCpfBase(base)
+	if err != nil {
+		return "", err
+	}
+
+	digits, err := checkDigits(cleaned, cpfFirstDigitTable, cpfSecondDigitTable)
+	if err != nil {
+		return "", err
+	}
+
+	return NewCpf(cleaned + digits)
+}
+
+// cleanCpfBase normalizes a CPF base and validates its length.
+func cleanCpfBase(base string) (string, error) {
+	if len(base) > MaxInputSize {
+		return "", newValidationError(CodeInputTooLarge, KindCPF, ErrInputTooLarge,
+			"CPF base input has %d characters", len(base))
+	}
+
+	cleaned := filterToDigitsOnly(cleanString(base))
+	if len(cleaned) != CPFBaseLength {
+		return "", newValidationError(CodeInvalidLength, KindCPF, ErrCPFInvalidBaseLength,
+			"CPF base must have exactly %d digits, got %d", CPFBaseLength, len(cleaned))
+	}
+
+	return cleaned, nil
+}
+
 // String returns the CPF formatted as XXX.XXX.XXX-XX.
-func (c *CPF) String() string {
-	str := string(*c)
+func (c CPF) String() string {
+	str := string(c)
 
 	// Safety check: only format if exactly 11 digits
 	if len(str) != CPFLength {
@@ -75,9 +185,53 @@ func (c *CPF) String() string {
 	return formatDocument(str, "XXX.XXX.XXX-XX")
 }
 
+// AppendFormatted appends the CPF formatted as XXX.XXX.XXX-XX to dst and returns the extended
+// buffer, like the strconv.Append functions. Values of the wrong length are appended as-is.
+func (c CPF) AppendFormatted(dst []byte) []byte {
+	if len(c) != CPFLength {
+		return append(dst, c...)
+	}
+	return appendDocument(dst, string(c), "XXX.XXX.XXX-XX")
+}
+
+// AppendRaw appends the unformatted CPF to dst and returns the extended buffer.
+func (c CPF) AppendRaw(dst []byte) []byte {
+	return append(dst, c...)
+}
+
 // Raw returns the CPF as unformatted string (digits only).
 // This is a zero-allocation method that returns the underlying
 // clean CPF digits without any formatting characters.
-func (c *CPF) Raw() string {
-	return string(*c)
+func (c CPF) Raw() string {
+	return string(c)
+}
+
+// Kind returns KindCPF.
+func (c CPF) Kind() Kind {
+	return KindCPF
+}
+
+// Masked returns the CPF following the gov.br convention, hiding the first
+// three digits and the check digits (e.g. "***.566.867-**").
+func (c CPF) Masked() string {
+	return c.MaskedWith(MaskGovBR)
+}
+
+// MaskedWith returns the formatted CPF with the characters hidden by style replaced by '*'.
+func (c CPF) MaskedWith(style MaskStyle) string {
+	str := string(c)
+
+	// Never expose a value that is not a well-formed CPF
+	if len(str) != CPFLength {
+		return maskAll(str)
+	}
+
+	return formatDocument(str, style.pattern(KindCPF, "XXX.XXX.XXX-XX"))
+}
+
+// MatchesMasked reports whether a masked value such as "***.566.867-**", read back by
+// a customer, is consistent with this CPF. Formatting is ignored and '*' matches any digit.
+// A value with no visible digits never matches.
+func (c CPF) MatchesMasked(masked string) bool {
+	return len(c) == CPFLength && matchesMasked(string(c), masked)
 }
@@ -281,3 +281,128 @@ func BenchmarkCPFRaw(b *testing.B) {
 		_ = cpf.Raw()
 	}
 }
+
+// Test CPF check digit calculation and completion from a 9-digit base
+func TestCompleteCpf(t *testing.T) {
+	tests := []struct {
+		name           string
+		base           string
+		expectedDigits string
+		expectedCPF    string
+		expectedErr    error
+	}{
+		{"Clean base", "716566867", "59", "71656686759", nil},
+		{"Formatted base", "716.566.867", "59", "71656686759", nil},
+		{"Base with leading zeros", "031671580", "85", "03167158085", nil},
+		{"Base with spaces", " 648 446 967 ", "93", "64844696793", nil},
+
+		// Errors
+		{"Empty base", "", "", "", ErrCPFInvalidBaseLength},
+		{"Too short", "71656686", "", "", ErrCPFInvalidBaseLength},
+		{"Full CPF instead of base", "71656686759", "", "", ErrCPFInvalidBaseLength},
+		{"Letters are ignored", "7165668A6", "", "", ErrCPFInvalidBaseLength},
+		{"Oversized input", strings.Repeat("1", MaxInputSize+1), "", "", ErrInputTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			digits, err := CpfCheckDigits(tt.base)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("CpfCheckDigits(%q) error = %v, want %v", tt.base, err, tt.expectedErr)
+			}
+			if digits != tt.expectedDigits {
+				t.Errorf("CpfCheckDigits(%q) = %q, want %q", tt.base, digits, tt.expectedDigits)
+			}
+
+			cpf, err := CompleteCpf(tt.base)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("CompleteCpf(%q) error = %v, want %v", tt.base, err, tt.expectedErr)
+			}
+			if cpf.Raw() != tt.expectedCPF {
+				t.Errorf("CompleteCpf(%q) = %q, want %q", tt.base, cpf.Raw(), tt.expectedCPF)
+			}
+		})
+	}
+}
+
+// Test that CompleteCpf rejects bases that can only produce invalid CPFs
+func TestCompleteCpf_SameDigits(t *testing.T) {
+	_, err := CompleteCpf("111.111.111")
+	if !errors.Is(err, ErrAllSameDigits) {
+		t.Errorf("CompleteCpf(same digits) error = %v, want %v", err, ErrAllSameDigits)
+	}
+
+	// Check digits are still reported for the base
+	digits, err := CpfCheckDigits("111111111")
+	if err != nil || digits != "11" {
+		t.Errorf("CpfCheckDigits(same digits) = %q, %v, want \"11\", nil", digits, err)
+	}
+}
+
+// Test that every known valid CPF can be rebuilt from its base
+func TestCompleteCpf_RoundTrip(t *testing.T) {
+	for _, validCPF := range validCPFs {
+		t.Run(validCPF, func(t *testing.T) {
+			cpf, err := CompleteCpf(validCPF[:CPFBaseLength])
+			if err != nil {
+				t.Fatalf("CompleteCpf(%q) unexpected error: %v", validCPF[:CPFBaseLength], err)
+			}
+			if cpf.Raw() != validCPF {
+				t.Errorf("CompleteCpf(%q) = %q, want %q", validCPF[:CPFBaseLength], cpf.Raw(), validCPF)
+			}
+		})
+	}
+}
+
+// Test that ParseCpfBytes agrees with NewCpf
+func TestParseCpfBytes(t *testing.T) {
+	inputs := []string{
+		"716.566.867-59", "71656686759", " 716.566.867-59 ", "031.671.580-85",
+		"", "123", "716.566.867-58", "11111111111", "71656686A59", "71656686759AB12",
+		strings.Repeat("1", MaxInputSize+1),
+	}
+
+	for _, input := range inputs {
+		t.Run(input[:min(len(input), 20)], func(t *testing.T) {
+			want, wantErr := NewCpf(input)
+			got, err := ParseCpfBytes([]byte(input))
+
+			if got != want {
+				t.Errorf("ParseCpfBytes(%q) = %q, want %q", input, got, want)
+			}
+			if (err == nil) != (wantErr == nil) || (err != nil && err.Error() != wantErr.Error()) {
+				t.Errorf("ParseCpfBytes(%q) error = %v, want %v", input, err, wantErr)
+			}
+			if CodeOf(err) != CodeOf(wantErr) {
+				t.Errorf("ParseCpfBytes(%q) code = %q, want %q", input, CodeOf(err), CodeOf(wantErr))
+			}
+		})
+	}
+}
+
+// Test appending CPFs to byte slices
+func TestCPFAppend(t *testing.T) {
+	tests := []struct {
+		name              string
+		cpf               CPF
+		expectedFormatted string
+		expectedRaw       string
+	}{
+		{"Valid CPF", "71656686759", "prefix:716.566.867-59", "prefix:71656686759"},
+		{"Wrong length CPF", "123", "prefix:123", "prefix:123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(tt.cpf.AppendFormatted([]byte("prefix:"))); got != tt.expectedFormatted {
+				t.Errorf("AppendFormatted() = %q, want %q", got, tt.expectedFormatted)
+			}
+			if got := string(tt.cpf.AppendRaw([]byte("prefix:"))); got != tt.expectedRaw {
+				t.Errorf("AppendRaw() = %q, want %q", got, tt.expectedRaw)
+			}
+			if got := string(tt.cpf.AppendFormatted(nil)); got != tt.cpf.String() {
+				t.Errorf("AppendFormatted(nil) = %q, want String() = %q", got, tt.cpf.String())
+			}
+		})
+	}
+}
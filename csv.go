@@ -0,0 +1,267 @@
+package cpfcnpj
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// ErrColumnNotFound is returned by NormalizeCSV when the document column does not exist.
+var ErrColumnNotFound = errors.New("document column not found")
+
+// Format selects how NormalizeCSV and ValidateStruct rewrite valid documents.
+type Format int
+
+// Output formats
+const (
+	FormatUnchanged Format = iota // keep the value as it was
+	FormatRaw                     // "71656686759"
+	FormatFormatted               // "716.566.867-59"
+)
+
+// Status column names and values added by NormalizeCSV when CSVOptions.AddStatus is set
+const (
+	CSVStatusColumn    = "document_status"
+	CSVErrorCodeColumn = "document_error_code"
+	CSVStatusValid     = "valid"
+	CSVStatusInvalid   = "invalid"
+)
+
+// CSVOptions configures NormalizeCSV.
+type CSVOptions struct {
+	// Column is the header name of the document column. When empty, ColumnIndex is used.
+	Column string
+	// ColumnIndex is the zero-based position of the document column.
+	ColumnIndex int
+	// NoHeader reports that the first record is data. Column must then be empty.
+	NoHeader bool
+	// Comma is the field delimiter. Zero means ','.
+	Comma rune
+
+	// Kind selects the validator: KindCPF uses NewCpf, either CNPJ kind uses NewCnpj and
+	// KindUnknown (the zero value) detects the type with Parse.
+	Kind Kind
+	// Format is how valid documents are rewritten.
+	Format Format
+	// AddStatus appends the CSVStatusColumn and CSVErrorCodeColumn columns to every row.
+	AddStatus bool
+
+	// Rejects, when set, receives the invalid rows (with the header, if any) instead of dst.
+	Rejects io.Writer
+}
+
+// CSVReport summarizes a NormalizeCSV run.
+type CSVReport struct {
+	Rows    int               // data rows read
+	Valid   int               // rows with a valid document
+	Invalid int               // rows with an invalid document
+	Kinds   map[Kind]int      // valid rows per document kind
+	Codes   map[ErrorCode]int // invalid rows per error code
+}
+
+// String returns a one-line summary such as
+// "3 rows: 2 valid (cpf: 1, cnpj_numeric: 1), 1 invalid (checksum: 1)".
+func (r CSVReport) String() string {
+	kinds := make(map[string]int, len(r.Kinds))
+	for kind, n := range r.Kinds {
+		kinds[kind.String()] = n
+	}
+	codes := make(map[string]int, len(r.Codes))
+	for code, n := range r.Codes {
+		codes[string(code)] = n
+	}
+
+	return fmt.Sprintf("%d rows: %d valid%s, %d invalid%s", r.Rows, r.Valid, countList(kinds), r.Invalid,
+		countList(codes))
+}
+
+// countList formats counts as " (a: 1, b: 2)" in key order, or "" when empty.
+func countList(counts map[string]int) string {
+	if len(counts) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = fmt.Sprintf("%s: %d", key, counts[key])
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// NormalizeCSV reads CSV records from src, validates the document column of every row and
+// writes the rows to dst with valid documents rewritten in opts.Format. Invalid rows keep their
+// original value and go to opts.Rejects when it is set, or to dst otherwise.
+//
+// Validation problems are counted in the report, not returned. The error is non-nil only for
+// malformed CSV, a missing column or a failed write; the report then covers the rows read so far.
+func NormalizeCSV(dst io.Writer, src io.Reader, opts CSVOptions) (CSVReport, error) {
+	report := CSVReport{Kinds: map[Kind]int{}, Codes: map[ErrorCode]int{}}
+
+	r := csv.NewReader(src)
+	w := csv.NewWriter(dst)
+	if opts.Comma != 0 {
+		r.Comma = opts.Comma
+		w.Comma = opts.Comma
+	}
+
+	var rejects *csv.Writer
+	if opts.Rejects != nil {
+		rejects = csv.NewWriter(opts.Rejects)
+		rejects.Comma = w.Comma
+	}
+
+	err := normalizeRecords(r, w, rejects, opts, &report)
+
+	// Flush even after an error so the rows processed so far are written
+	if flushErr := flushAll(w, rejects); err == nil {
+		err = flushErr
+	}
+	return report, err
+}
+
+// normalizeRecords does the work of NormalizeCSV, counting rows in report.
+func normalizeRecords(r *csv.Reader, w, rejects *csv.Writer, opts CSVOptions, report *CSVReport) error {
+	column, ok, err := normalizeHeader(r, w, rejects, opts)
+	if !ok || err != nil {
+		return err
+	}
+
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if column < 0 || column >= len(record) {
+			line, _ := r.FieldPos(0)
+			return fmt.Errorf("line %d has %d fields, no column %d: %w", line, len(record), column,
+				ErrColumnNotFound)
+		}
+
+		record, rejected := normalizeRow(record, column, opts, report)
+		out := w
+		if rejected && rejects != nil {
+			out = rejects
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+}
+
+// normalizeHeader reads the header, unless opts.NoHeader is set, and writes it to w and rejects.
+// It returns the index of the document column, and false when src has no records.
+func normalizeHeader(r *csv.Reader, w, rejects *csv.Writer, opts CSVOptions) (column int, ok bool, err error) {
+	if opts.NoHeader {
+		if opts.Column != "" {
+			return 0, false, fmt.Errorf("column %q given without a header: %w", opts.Column, ErrColumnNotFound)
+		}
+		return opts.ColumnIndex, true, nil
+	}
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	if column, err = findColumn(header, opts); err != nil {
+		return 0, false, err
+	}
+	if opts.AddStatus {
+		header = append(header, CSVStatusColumn, CSVErrorCodeColumn)
+	}
+	if err := writeRecord(w, rejects, header); err != nil {
+		return 0, false, err
+	}
+	return column, true, nil
+}
+
+// normalizeRow validates the document column of record, rewrites it in opts.Format when valid
+// and appends the status columns when opts.AddStatus is set. It reports whether the document
+// is invalid, so the row belongs in the rejects.
+func normalizeRow(record []string, column int, opts CSVOptions, report *CSVReport) (out []string, rejected bool) {
+	report.Rows++
+	doc, err := ParseKind(opts.Kind, record[column])
+
+	status, code := CSVStatusValid, ErrorCode("")
+	if err != nil {
+		report.Invalid++
+		status, code = CSVStatusInvalid, CodeOf(err)
+		report.Codes[code]++
+	} else {
+		report.Valid++
+		report.Kinds[doc.Kind()]++
+		switch opts.Format {
+		case FormatRaw:
+			record[column] = doc.Raw()
+		case FormatFormatted:
+			record[column] = doc.String()
+		case FormatUnchanged:
+		}
+	}
+
+	if opts.AddStatus {
+		record = append(record, status, string(code))
+	}
+	return record, err != nil
+}
+
+// findColumn returns the index of the document column in header.
+func findColumn(header []string, opts CSVOptions) (int, error) {
+	if opts.Column == "" {
+		if opts.ColumnIndex < 0 || opts.ColumnIndex >= len(header) {
+			return 0, fmt.Errorf("column index %d out of range for %d columns: %w", opts.ColumnIndex,
+				len(header), ErrColumnNotFound)
+		}
+		return opts.ColumnIndex, nil
+	}
+
+	for i, name := range header {
+		// Spreadsheet exports often start with a UTF-8 byte order mark
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		if strings.TrimSpace(name) == opts.Column {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("column %q: %w", opts.Column, ErrColumnNotFound)
+}
+
+// writeRecord writes record to w and, when set, rejects.
+func writeRecord(w, rejects *csv.Writer, record []string) error {
+	if err := w.Write(record); err != nil {
+		return err
+	}
+	if rejects != nil {
+		return rejects.Write(record)
+	}
+	return nil
+}
+
+// flushAll flushes w and, when set, rejects.
+func flushAll(w, rejects *csv.Writer) error {
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	if rejects != nil {
+		rejects.Flush()
+		return rejects.Error()
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package cpfcnpj
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// csvInput is a partner upload with documents in mixed formats
+const csvInput = "name,document,city\n" +
+	"Ana,716.566.867-59,Rio\n" +
+	"Acme,22796729000159,Recife\n" +
+	"Bia,716.566.867-58,Vitória\n" +
+	"Nova,12.abc.345/01de-35,Natal\n"
+
+// Test CSV normalization output and report counts
+func TestNormalizeCSV(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		opts            CSVOptions
+		expectedOutput  string
+		expectedRejects string
+		expectedReport  string
+	}{
+		{
+			name:  "Column by name, raw",
+			input: csvInput,
+			opts:  CSVOptions{Column: "document", Format: FormatRaw},
+			expectedOutput: "name,document,city\n" +
+				"Ana,71656686759,Rio\n" +
+				"Acme,22796729000159,Recife\n" +
+				"Bia,716.566.867-58,Vitória\n" +
+				"Nova,12ABC34501DE35,Natal\n",
+			expectedReport: "4 rows: 3 valid (cnpj_alphanumeric: 1, cnpj_numeric: 1, cpf: 1), " +
+				"1 invalid (checksum: 1)",
+		},
+		{
+			name:  "Column by index, formatted",
+			input: csvInput,
+			opts:  CSVOptions{ColumnIndex: 1, Format: FormatFormatted},
+			expectedOutput: "name,document,city\n" +
+				"Ana,716.566.867-59,Rio\n" +
+				"Acme,22.796.729/0001-59,Recife\n" +
+				"Bia,716.566.867-58,Vitória\n" +
+				"Nova,12.ABC.345/01DE-35,Natal\n",
+			expectedReport: "4 rows: 3 valid (cnpj_alphanumeric: 1, cnpj_numeric: 1, cpf: 1), " +
+				"1 invalid (checksum: 1)",
+		},
+		{
+			name:  "Unchanged with status columns",
+			input: csvInput,
+			opts:  CSVOptions{Column: "document", AddStatus: true},
+			expectedOutput: "name,document,city,document_status,document_error_code\n" +
+				"Ana,716.566.867-59,Rio,valid,\n" +
+				"Acme,22796729000159,Recife,valid,\n" +
+				"Bia,716.566.867-58,Vitória,invalid,checksum\n" +
+				"Nova,12.abc.345/01de-35,Natal,valid,\n",
+			expectedReport: "4 rows: 3 valid (cnpj_alphanumeric: 1, cnpj_numeric: 1, cpf: 1), " +
+				"1 invalid (checksum: 1)",
+		},
+		{
+			name:  "CPF only with rejects",
+			input: csvInput,
+			opts:  CSVOptions{Column: "document", Kind: KindCPF, Format: FormatRaw, AddStatus: true},
+			expectedOutput: "name,document,city,document_status,document_error_code\n" +
+				"Ana,71656686759,Rio,valid,\n",
+			expectedRejects: "name,document,city,document_status,document_error_code\n" +
+				"Acme,22796729000159,Recife,invalid,length\n" +
+				"Bia,716.566.867-58,Vitória,invalid,checksum\n" +
+				"Nova,12.abc.345/01de-35,Natal,invalid,length\n",
+			expectedReport: "4 rows: 1 valid (cpf: 1), 3 invalid (checksum: 1, length: 2)",
+		},
+		{
+			name:           "No header, custom comma",
+			input:          "71656686759;Ana\n22796729000159;Acme\n",
+			opts:           CSVOptions{NoHeader: true, Comma: ';', Format: FormatFormatted},
+			expectedOutput: "716.566.867-59;Ana\n22.796.729/0001-59;Acme\n",
+			expectedReport: "2 rows: 2 valid (cnpj_numeric: 1, cpf: 1), 0 invalid",
+		},
+		{
+			name:           "Header with byte order mark and padding",
+			input:          "\ufeff document ,name\n71656686759,Ana\n",
+			opts:           CSVOptions{Column: "document", Format: FormatFormatted},
+			expectedOutput: "\ufeff document ,name\n716.566.867-59,Ana\n",
+			expectedReport: "1 rows: 1 valid (cpf: 1), 0 invalid",
+		},
+		{
+			name:           "Empty input",
+			input:          "",
+			opts:           CSVOptions{Column: "document"},
+			expectedOutput: "",
+			expectedReport: "0 rows: 0 valid, 0 invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output, rejects bytes.Buffer
+			opts := tt.opts
+			if tt.expectedRejects != "" {
+				opts.Rejects = &rejects
+			}
+
+			report, err := NormalizeCSV(&output, strings.NewReader(tt.input), opts)
+			if err != nil {
+				t.Fatalf("NormalizeCSV() error = %v", err)
+			}
+
+			if output.String() != tt.expectedOutput {
+				t.Errorf("output =\n%s\nwant\n%s", output.String(), tt.expectedOutput)
+			}
+			if rejects.String() != tt.expectedRejects {
+				t.Errorf("rejects =\n%s\nwant\n%s", rejects.String(), tt.expectedRejects)
+			}
+			if report.String() != tt.expectedReport {
+				t.Errorf("report = %q, want %q", report.String(), tt.expectedReport)
+			}
+			if report.Valid+report.Invalid != report.Rows {
+				t.Errorf("report counts %d valid + %d invalid != %d rows", report.Valid, report.Invalid, report.Rows)
+			}
+		})
+	}
+}
+
+// Test that a missing document column is an error, not a validation failure
+func TestNormalizeCSV_ColumnNotFound(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		opts  CSVOptions
+	}{
+		{name: "Unknown column name", input: csvInput, opts: CSVOptions{Column: "cpf"}},
+		{name: "Column index out of range", input: csvInput, opts: CSVOptions{ColumnIndex: 3}},
+		{name: "Negative column index", input: csvInput, opts: CSVOptions{ColumnIndex: -1}},
+		{name: "Column name without header", input: csvInput, opts: CSVOptions{Column: "document", NoHeader: true}},
+		{name: "Column index past a headerless row", input: "71656686759\n", opts: CSVOptions{ColumnIndex: 1,
+			NoHeader: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var output bytes.Buffer
+			_, err := NormalizeCSV(&output, strings.NewReader(tt.input), tt.opts)
+			if !errors.Is(err, ErrColumnNotFound) {
+				t.Errorf("NormalizeCSV() error = %v, want %v", err, ErrColumnNotFound)
+			}
+		})
+	}
+}
+
+// Test that malformed CSV stops the run after writing the rows read so far
+func TestNormalizeCSV_Malformed(t *testing.T) {
+	input := "document,name\n71656686759,Ana\n22796729000159\n"
+
+	var output bytes.Buffer
+	report, err := NormalizeCSV(&output, strings.NewReader(input), CSVOptions{Format: FormatFormatted})
+
+	var parseErr *csv.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Line != 3 {
+		t.Fatalf("NormalizeCSV() error = %v, want a csv.ParseError on line 3", err)
+	}
+	if expected := "document,name\n716.566.867-59,Ana\n"; output.String() != expected {
+		t.Errorf("output = %q, want %q", output.String(), expected)
+	}
+	if report.Rows != 1 || report.Valid != 1 {
+		t.Errorf("report = %v, want 1 valid row", report)
+	}
+}
+
+// Benchmark normalizing a large CSV file
+func BenchmarkNormalizeCSV(b *testing.B) {
+	input := "name,document,city\n" + strings.Repeat("Ana,716.566.867-59,Rio\nAcme,22796729000159,Recife\n", 500)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := NormalizeCSV(io.Discard, strings.NewReader(input), CSVOptions{Column: "document",
+			Format: FormatRaw}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
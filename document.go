@@ -0,0 +1,116 @@
+package cpfcnpj
+
+import "errors"
+
+// ErrUnknownDocument is returned by Parse when the input length matches neither a CPF nor a CNPJ.
+var ErrUnknownDocument = errors.New("document must have 11 (CPF) or 14 (CNPJ) characters")
+
+// Kind identifies the type of a Brazilian taxpayer document.
+type Kind int
+
+// Document kinds
+const (
+	// KindUnknown is the zero value and represents an undetermined document type.
+	KindUnknown Kind = iota
+	// KindCPF identifies an individual taxpayer number (11 digits).
+	KindCPF
+	// KindCNPJNumeric identifies a company number made only of digits.
+	KindCNPJNumeric
+	// KindCNPJAlphanumeric identifies a company number in the CNPJ Alfanumérico format.
+	KindCNPJAlphanumeric
+)
+
+// String returns a stable lowercase identifier for the kind, e.g. "cpf" or "cnpj_alphanumeric".
+func (k Kind) String() string {
+	switch k {
+	case KindCPF:
+		return "cpf"
+	case KindCNPJNumeric:
+		return "cnpj_numeric"
+	case KindCNPJAlphanumeric:
+		return "cnpj_alphanumeric"
+	case KindUnknown:
+	}
+	return "unknown"
+}
+
+// IsCNPJ reports whether the kind is one of the CNPJ formats.
+func (k Kind) IsCNPJ() bool {
+	return k == KindCNPJNumeric || k == KindCNPJAlphanumeric
+}
+
+// Document is implemented by every validated taxpayer document (CPF, CNPJ and TaxID).
+type Document interface {
+	// Kind returns the detected document type.
+	Kind() Kind
+	// Raw returns the document without formatting characters.
+	Raw() string
+	// String returns the document in its official formatted representation.
+	String() string
+	// Masked returns the formatted document with sensitive characters replaced by '*'.
+	Masked() string
+	// MaskedWith returns the formatted document masked according to style.
+	MaskedWith(style MaskStyle) string
+	// MatchesMasked reports whether a masked value is consistent with the document.
+	MatchesMasked(masked string) bool
+}
+
+// Compile-time interface checks
+var (
+	_ Document = CPF("")
+	_ Document = CNPJ("")
+	_ Document = TaxID("")
+)
+
+// Parse cleans the input, detects whether it is a CPF or a CNPJ from its length,
+// and validates it with NewCpf or NewCnpj.
+//
+// The returned Document holds either a CPF or a CNPJ value, so callers can use a
+// type switch when they need the concrete type.
+func Parse(s string) (Document, error) {
+	if len(s) > MaxInputSize {
+		return nil, newValidationError(CodeInputTooLarge, KindUnknown, ErrInputTooLarge,
+			"document input has %d characters", len(s))
+	}
+
+	cleaned := Clean(s)
+
+	switch len(cleaned) {
+	case CPFLength:
+		cpf, err := NewCpf(cleaned)
+		if err != nil {
+			return nil, err
+		}
+		return cpf, nil
+	case CNPJLength:
+		cnpj, err := NewCnpj(cleaned)
+		if err != nil {
+			return nil, err
+		}
+		return cnpj, nil
+	default:
+		return nil, newValidationError(CodeInvalidLength, KindUnknown, ErrUnknownDocument,
+			"unable to determine document type from length %d", len(cleaned))
+	}
+}
+
+// ParseKind validates s with the constructor selected by kind: NewCpf for KindCPF, NewCnpj
+// for either CNPJ kind and Parse for KindUnknown. The Document is nil when err is set.
+func ParseKind(kind Kind, s string) (Document, error) {
+	switch {
+	case kind == KindCPF:
+		cpf, err := NewCpf(s)
+		if err != nil {
+			return nil, err
+		}
+		return cpf, nil
+	case kind.IsCNPJ():
+		cnpj, err := NewCnpj(s)
+		if err != nil {
+			return nil, err
+		}
+		return cnpj, nil
+	default:
+		return Parse(s)
+	}
+}
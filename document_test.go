@@ -0,0 +1,165 @@
+package cpfcnpj
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Test Parse auto-detection across document kinds
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		expectedKind Kind
+		expectedRaw  string
+		expectedErr  error
+	}{
+		// Valid documents
+		{"Formatted CPF", "716.566.867-59", KindCPF, "71656686759", nil},
+		{"Clean CPF", "71656686759", KindCPF, "71656686759", nil},
+		{"Formatted numeric CNPJ", "22.796.729/0001-59", KindCNPJNumeric, "22796729000159", nil},
+		{"Clean numeric CNPJ", "22796729000159", KindCNPJNumeric, "22796729000159", nil},
+		{"Formatted alphanumeric CNPJ", "12.ABC.345/01DE-35", KindCNPJAlphanumeric, "12ABC34501DE35", nil},
+		{"Lowercase alphanumeric CNPJ", "12.abc.345/01de-35", KindCNPJAlphanumeric, "12ABC34501DE35", nil},
+
+		// Invalid documents keep the constructor errors
+		{"Invalid CPF checksum", "716.566.867-58", KindUnknown, "", ErrCPFInvalidChecksum},
+		{"CPF all same digits", "111.111.111-11", KindUnknown, "", ErrAllSameDigits},
+		{"Invalid CNPJ checksum", "12.ABC.345/01DE-99", KindUnknown, "", ErrCNPJInvalidChecksum},
+		{"CNPJ letters in check digits", "12ABC34501DEAB", KindUnknown, "", ErrCNPJInvalidAlphanumeric},
+
+		// Undetectable inputs
+		{"Empty string", "", KindUnknown, "", ErrUnknownDocument},
+		{"Too short", "12345", KindUnknown, "", ErrUnknownDocument},
+		{"Between CPF and CNPJ", "123456789012", KindUnknown, "", ErrUnknownDocument},
+		{"Oversized input", strings.Repeat("1", MaxInputSize+1), KindUnknown, "", ErrInputTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Parse(tt.input)
+
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Fatalf("Parse(%q) error = %v, want %v", tt.input, err, tt.expectedErr)
+				}
+				if doc != nil {
+					t.Errorf("Parse(%q) returned document %v alongside error", tt.input, doc)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.input, err)
+			}
+			if doc.Kind() != tt.expectedKind {
+				t.Errorf("Parse(%q).Kind() = %v, want %v", tt.input, doc.Kind(), tt.expectedKind)
+			}
+			if doc.Raw() != tt.expectedRaw {
+				t.Errorf("Parse(%q).Raw() = %q, want %q", tt.input, doc.Raw(), tt.expectedRaw)
+			}
+		})
+	}
+}
+
+// Test that Parse returns the concrete CPF and CNPJ types
+func TestParse_ConcreteTypes(t *testing.T) {
+	doc, err := Parse("716.566.867-59")
+	if err != nil {
+		t.Fatalf("Parse unexpected error: %v", err)
+	}
+	if _, ok := doc.(CPF); !ok {
+		t.Errorf("Parse(CPF) returned %T, want CPF", doc)
+	}
+
+	doc, err = Parse("12.ABC.345/01DE-35")
+	if err != nil {
+		t.Fatalf("Parse unexpected error: %v", err)
+	}
+	if _, ok := doc.(CNPJ); !ok {
+		t.Errorf("Parse(CNPJ) returned %T, want CNPJ", doc)
+	}
+}
+
+// Test that ParseKind selects the constructor from the kind
+func TestParseKind(t *testing.T) {
+	tests := []struct {
+		name        string
+		kind        Kind
+		input       string
+		expectedErr error
+	}{
+		{"CPF as CPF", KindCPF, "716.566.867-59", nil},
+		{"CNPJ as CPF", KindCPF, "22796729000159", ErrCPFInvalidLength},
+		{"CNPJ as numeric CNPJ", KindCNPJNumeric, "22.796.729/0001-59", nil},
+		{"Alphanumeric CNPJ as numeric kind", KindCNPJNumeric, "12.ABC.345/01DE-35", nil},
+		{"Alphanumeric CNPJ as alphanumeric kind", KindCNPJAlphanumeric, "12ABC34501DE35", nil},
+		{"CPF as CNPJ", KindCNPJAlphanumeric, "71656686759", ErrCNPJInvalidLength},
+		{"Auto-detected CPF", KindUnknown, "71656686759", nil},
+		{"Auto-detected unknown length", KindUnknown, "123", ErrUnknownDocument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseKind(tt.kind, tt.input)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("ParseKind(%v, %q) error = %v, want %v", tt.kind, tt.input, err, tt.expectedErr)
+			}
+			if (err == nil) != (doc != nil) {
+				t.Errorf("ParseKind(%v, %q) = %v, %v, want exactly one of them set", tt.kind, tt.input, doc, err)
+			}
+		})
+	}
+}
+
+// Test Kind String and IsCNPJ helpers
+func TestKind(t *testing.T) {
+	tests := []struct {
+		kind     Kind
+		expected string
+		isCNPJ   bool
+	}{
+		{KindUnknown, "unknown", false},
+		{KindCPF, "cpf", false},
+		{KindCNPJNumeric, "cnpj_numeric", true},
+		{KindCNPJAlphanumeric, "cnpj_alphanumeric", true},
+		{Kind(42), "unknown", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := tt.kind.String(); got != tt.expected {
+				t.Errorf("Kind(%d).String() = %q, want %q", tt.kind, got, tt.expected)
+			}
+			if got := tt.kind.IsCNPJ(); got != tt.isCNPJ {
+				t.Errorf("Kind(%d).IsCNPJ() = %v, want %v", tt.kind, got, tt.isCNPJ)
+			}
+		})
+	}
+}
+
+// Test default masking exposed through the Document interface
+func TestDocumentMasked(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      Document
+		expected string
+	}{
+		{"CPF gov.br convention", CPF("71656686759"), "***.566.867-**"},
+		{"CPF leading zeros", CPF("03167158085"), "***.671.580-**"},
+		{"Numeric CNPJ root visible", CNPJ("22796729000159"), "22.796.729/****-**"},
+		{"Alphanumeric CNPJ root visible", CNPJ("12ABC34501DE35"), "12.ABC.345/****-**"},
+		{"Wrong length CPF fully masked", CPF("123"), "***"},
+		{"Wrong length CNPJ fully masked", CNPJ("12345"), "*****"},
+		{"Empty CPF", CPF(""), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.doc.Masked(); got != tt.expected {
+				t.Errorf("Masked() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package cpfcnpj
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorCode is a stable, machine-readable identifier for the reason a document failed validation.
+// Codes are safe to expose to API clients; error messages are not guaranteed to stay the same.
+type ErrorCode string
+
+// Validation error codes
+const (
+	CodeInvalidLength   ErrorCode = "length"
+	CodeInvalidCharset  ErrorCode = "charset"
+	CodeSameDigits      ErrorCode = "same_digits"
+	CodeInvalidChecksum ErrorCode = "checksum"
+	CodeInputTooLarge   ErrorCode = "too_large"
+	CodeInvalidFormat   ErrorCode = "format" // rejected by WithStrictFormat
+	CodeDenied          ErrorCode = "denied" // rejected by WithDenylist
+)
+
+// ValidationError describes why a document was rejected.
+//
+// It wraps one of the package sentinel errors, so errors.Is keeps working:
+//
+//	var verr *cpfcnpj.ValidationError
+//	if errors.As(err, &verr) && verr.Code == cpfcnpj.CodeInvalidChecksum {
+//	    fmt.Println(verr.Expected, verr.Got)
+//	}
+//	errors.Is(err, cpfcnpj.ErrCPFInvalidChecksum) // true
+type ValidationError struct {
+	// Code identifies the failure category.
+	Code ErrorCode
+	// Kind is the document type being validated.
+	Kind Kind
+	// Position is the index of the offending character in the cleaned input,
+	// or -1 when the failure is not tied to a single character.
+	Position int
+	// Char is the offending character when Position is not -1.
+	Char byte
+	// Expected holds the correct check digits for checksum failures.
+	Expected string
+	// Got holds the check digits that were received for checksum failures.
+	Got string
+	// Err is the sentinel error wrapped by this error.
+	Err error
+
+	msg string
+}
+
+// Error returns the detailed message followed by the wrapped sentinel message.
+func (e *ValidationError) Error() string {
+	if e.Err == nil {
+		return e.msg
+	}
+	return e.msg + ": " + e.Err.Error()
+}
+
+// Unwrap returns the wrapped sentinel error.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// CodeOf returns the ErrorCode carried by err, or an empty code if err
+// does not wrap a *ValidationError.
+func CodeOf(err error) ErrorCode {
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		return verr.Code
+	}
+	return ""
+}
+
+// newValidationError creates a ValidationError that is not tied to a character position.
+func newValidationError(code ErrorCode, kind Kind, sentinel error, format string, args ...any) *ValidationError {
+	return &ValidationError{
+		Code:     code,
+		Kind:     kind,
+		Position: -1,
+		Err:      sentinel,
+		msg:      fmt.Sprintf(format, args...),
+	}
+}
+
+// newCharsetError creates a ValidationError pointing at the offending character of s.
+func newCharsetError(kind Kind, s string, pos int, sentinel error, format string, args ...any) *ValidationError {
+	err := newValidationError(CodeInvalidCharset, kind, sentinel, format, args...)
+	err.Position = pos
+	err.Char = s[pos]
+	return err
+}
+
+// newChecksumError creates a ValidationError carrying the expected and received check digits.
+func newChecksumError(kind Kind, sentinel error, expected, got, format string, args ...any) *ValidationError {
+	err := newValidationError(CodeInvalidChecksum, kind, sentinel, format, args...)
+	err.Expected = expected
+	err.Got = got
+	return err
+}
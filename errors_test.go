@@ -0,0 +1,213 @@
+package cpfcnpj
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Test ValidationError details returned by the constructors
+func TestValidationError_Details(t *testing.T) {
+	tests := []struct {
+		name             string
+		validate         func(string) error
+		input            string
+		expectedCode     ErrorCode
+		expectedKind     Kind
+		expectedSentinel error
+		expectedPosition int
+		expectedChar     byte
+		expectedExpected string
+		expectedGot      string
+	}{
+		// CPF errors
+		{
+			name:             "CPF too large",
+			validate:         validateCpf,
+			input:            strings.Repeat("1", MaxInputSize+1),
+			expectedCode:     CodeInputTooLarge,
+			expectedKind:     KindCPF,
+			expectedSentinel: ErrInputTooLarge,
+			expectedPosition: -1,
+		},
+		{
+			name:             "CPF wrong length",
+			validate:         validateCpf,
+			input:            "123",
+			expectedCode:     CodeInvalidLength,
+			expectedKind:     KindCPF,
+			expectedSentinel: ErrCPFInvalidLength,
+			expectedPosition: -1,
+		},
+		{
+			name:             "CPF same digits",
+			validate:         validateCpf,
+			input:            "111.111.111-11",
+			expectedCode:     CodeSameDigits,
+			expectedKind:     KindCPF,
+			expectedSentinel: ErrAllSameDigits,
+			expectedPosition: -1,
+		},
+		{
+			name:             "CPF both check digits wrong",
+			validate:         validateCpf,
+			input:            "716.566.867-34",
+			expectedCode:     CodeInvalidChecksum,
+			expectedKind:     KindCPF,
+			expectedSentinel: ErrCPFInvalidChecksum,
+			expectedPosition: 9,
+			expectedChar:     '3',
+			expectedExpected: "59",
+			expectedGot:      "34",
+		},
+		{
+			name:             "CPF second check digit wrong",
+			validate:         validateCpf,
+			input:            "71656686758",
+			expectedCode:     CodeInvalidChecksum,
+			expectedKind:     KindCPF,
+			expectedSentinel: ErrCPFInvalidChecksum,
+			expectedPosition: 10,
+			expectedChar:     '8',
+			expectedExpected: "59",
+			expectedGot:      "58",
+		},
+
+		// CNPJ errors
+		{
+			name:             "CNPJ too large",
+			validate:         validateCnpj,
+			input:            strings.Repeat("A", MaxInputSize+1),
+			expectedCode:     CodeInputTooLarge,
+			expectedKind:     KindCNPJNumeric,
+			expectedSentinel: ErrInputTooLarge,
+			expectedPosition: -1,
+		},
+		{
+			name:             "CNPJ wrong length",
+			validate:         validateCnpj,
+			input:            "12ABC34501DE355",
+			expectedCode:     CodeInvalidLength,
+			expectedKind:     KindCNPJAlphanumeric,
+			expectedSentinel: ErrCNPJInvalidLength,
+			expectedPosition: -1,
+		},
+		{
+			name:             "CNPJ letter in check digits",
+			validate:         validateCnpj,
+			input:            "12ABC34501DE3B",
+			expectedCode:     CodeInvalidCharset,
+			expectedKind:     KindCNPJAlphanumeric,
+			expectedSentinel: ErrCNPJInvalidAlphanumeric,
+			expectedPosition: 13,
+			expectedChar:     'B',
+		},
+		{
+			name:             "CNPJ same digits",
+			validate:         validateCnpj,
+			input:            "00.000.000/0000-00",
+			expectedCode:     CodeSameDigits,
+			expectedKind:     KindCNPJNumeric,
+			expectedSentinel: ErrAllSameDigits,
+			expectedPosition: -1,
+		},
+		{
+			name:             "Alphanumeric CNPJ wrong check digits",
+			validate:         validateCnpj,
+			input:            "12.ABC.345/01DE-99",
+			expectedCode:     CodeInvalidChecksum,
+			expectedKind:     KindCNPJAlphanumeric,
+			expectedSentinel: ErrCNPJInvalidChecksum,
+			expectedPosition: 12,
+			expectedChar:     '9',
+			expectedExpected: "35",
+			expectedGot:      "99",
+		},
+
+		// Parse errors
+		{
+			name:             "Parse unknown length",
+			validate:         validateAny,
+			input:            "12345",
+			expectedCode:     CodeInvalidLength,
+			expectedKind:     KindUnknown,
+			expectedSentinel: ErrUnknownDocument,
+			expectedPosition: -1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.validate(tt.input)
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected *ValidationError, got %T: %v", err, err)
+			}
+
+			if verr.Code != tt.expectedCode {
+				t.Errorf("Code = %q, want %q", verr.Code, tt.expectedCode)
+			}
+			if verr.Kind != tt.expectedKind {
+				t.Errorf("Kind = %v, want %v", verr.Kind, tt.expectedKind)
+			}
+			if !errors.Is(err, tt.expectedSentinel) {
+				t.Errorf("errors.Is(err, %v) = false", tt.expectedSentinel)
+			}
+			if verr.Position != tt.expectedPosition {
+				t.Errorf("Position = %d, want %d", verr.Position, tt.expectedPosition)
+			}
+			if verr.Char != tt.expectedChar {
+				t.Errorf("Char = %q, want %q", verr.Char, tt.expectedChar)
+			}
+			if verr.Expected != tt.expectedExpected {
+				t.Errorf("Expected = %q, want %q", verr.Expected, tt.expectedExpected)
+			}
+			if verr.Got != tt.expectedGot {
+				t.Errorf("Got = %q, want %q", verr.Got, tt.expectedGot)
+			}
+			if CodeOf(err) != tt.expectedCode {
+				t.Errorf("CodeOf(err) = %q, want %q", CodeOf(err), tt.expectedCode)
+			}
+		})
+	}
+}
+
+// Test CodeOf with errors that are not validation errors
+func TestCodeOf_NonValidationErrors(t *testing.T) {
+	if code := CodeOf(nil); code != "" {
+		t.Errorf("CodeOf(nil) = %q, want empty", code)
+	}
+	if code := CodeOf(ErrCPFInvalidChecksum); code != "" {
+		t.Errorf("CodeOf(sentinel) = %q, want empty", code)
+	}
+}
+
+// Test that the error message keeps the detail and sentinel text
+func TestValidationError_Message(t *testing.T) {
+	_, err := NewCpf("71656686734")
+	expected := "CPF check digits are invalid: CPF checksum validation failed"
+	if err == nil || err.Error() != expected {
+		t.Errorf("NewCpf error = %v, want %q", err, expected)
+	}
+
+	verr := &ValidationError{msg: "no sentinel"}
+	if verr.Error() != "no sentinel" {
+		t.Errorf("ValidationError without sentinel = %q, want %q", verr.Error(), "no sentinel")
+	}
+}
+
+func validateCpf(s string) error {
+	_, err := NewCpf(s)
+	return err
+}
+
+func validateCnpj(s string) error {
+	_, err := NewCnpj(s)
+	return err
+}
+
+func validateAny(s string) error {
+	_, err := Parse(s)
+	return err
+}
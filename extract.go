@@ -0,0 +1,214 @@
+package cpfcnpj
+
+// Match is a CPF or CNPJ found in free text by Extract.
+type Match struct {
+	// Start and End are the byte offsets of the match in the text, so text[Start:End] == Text.
+	Start, End int
+	// Kind is KindCPF, KindCNPJNumeric or KindCNPJAlphanumeric.
+	Kind Kind
+	// Text is the match exactly as it appears in the input.
+	Text string
+	// Raw is the match without formatting characters.
+	Raw string
+	// Formatted is the match in the official formatted representation.
+	Formatted string
+	// Valid reports whether the match passes NewCpf or NewCnpj.
+	Valid bool
+}
+
+// Document returns the validated document for a valid match, or nil if the match is invalid.
+func (m Match) Document() Document {
+	if !m.Valid {
+		return nil
+	}
+	if m.Kind == KindCPF {
+		return CPF(m.Raw)
+	}
+	return CNPJ(m.Raw)
+}
+
+// extractPattern describes one document shape recognised in free text.
+// Pattern characters: 'D' matches a digit, 'A' matches a digit or a letter of either case
+// (like NewCnpj, which uppercases lowercase letters), anything else must match literally
+// (the same convention as formatDocument).
+type extractPattern struct {
+	pattern string
+	cnpj    bool
+	// requireValid drops candidates with invalid check digits. It is used for
+	// unformatted alphanumeric CNPJs, which otherwise match ordinary uppercase words.
+	requireValid bool
+}
+
+// extractPatterns are tried in order at each candidate position; formatted shapes come first
+// so "716.566.867-59" is never reported as a shorter unformatted run.
+var extractPatterns = []extractPattern{
+	{pattern: "AA.AAA.AAA/AAAA-DD", cnpj: true},
+	{pattern: "DDD.DDD.DDD-DD"},
+	{pattern: "DDDDDDDDDDDDDD", cnpj: true},
+	{pattern: "AAAAAAAAAAAADD", cnpj: true, requireValid: true},
+	{pattern: "DDDDDDDDDDD"},
+}
+
+// maxMatchLength is the length of the longest extract pattern (formatted CNPJ).
+const maxMatchLength = 18
+
+// Extract finds every CPF and CNPJ in text, in order of appearance.
+//
+// Formatted ("716.566.867-59", "12.ABC.345/01DE-35") and unformatted ("71656686759",
+// "22796729000159") forms are recognised, and alphanumeric CNPJs may be in either case.
+// A candidate must not touch other letters or digits, directly or through a '.', '-' or '/'
+// on either side, so digits inside longer runs such as phone numbers, 44-digit NF-e access keys or
+// "12.345.678.901-23" are not reported. Matches with invalid check digits are reported with
+// Valid set to false, except unformatted alphanumeric CNPJs, which are only reported when valid.
+func Extract(text string) []Match {
+	var matches []Match
+	scanDocuments(text, "", func(m Match) bool {
+		matches = append(matches, m)
+		return true
+	})
+	return matches
+}
+
+// scanDocuments calls fn for each match in text until fn returns false.
+// prev holds the bytes preceding text ("" at the start of input); its last two bytes are
+// used for the left boundary check.
+func scanDocuments(text, prev string, fn func(Match) bool) {
+	i := 0
+	for i < len(text) {
+		if !isAlphanumericByte(text[i]) {
+			i++
+			continue
+		}
+
+		if !continuesBefore(text, prev, i) {
+			if m, ok := matchAt(text, i); ok {
+				if !fn(m) {
+					return
+				}
+				i = m.End
+				continue
+			}
+		}
+
+		// Skip the rest of this alphanumeric run: nothing inside it can start a match
+		for i < len(text) && isAlphanumericByte(text[i]) {
+			i++
+		}
+	}
+}
+
+// continuesBefore reports whether a candidate starting at text[i] continues the text before it:
+// a letter or digit comes right before it, or a separator that itself follows a letter or digit.
+func continuesBefore(text, prev string, i int) bool {
+	before := byteBefore(text, prev, i)
+	if isAlphanumericByte(before) {
+		return true
+	}
+	return isSeparatorByte(before) && isAlphanumericByte(byteBefore(text, prev, i-1))
+}
+
+// continuesAfter reports whether the text after a candidate ending at text[end] continues it:
+// a letter or digit comes right after it, or a separator followed by a letter or digit.
+func continuesAfter(text string, end int) bool {
+	if end >= len(text) {
+		return false
+	}
+	if isAlphanumericByte(text[end]) {
+		return true
+	}
+	return isSeparatorByte(text[end]) && end+1 < len(text) && isAlphanumericByte(text[end+1])
+}
+
+// byteBefore returns the byte preceding text[i], reading from the end of prev when i is
+// at the start of text, or 0 when there is none.
+func byteBefore(text, prev string, i int) byte {
+	if i > 0 {
+		return text[i-1]
+	}
+	if j := len(prev) + i - 1; j >= 0 {
+		return prev[j]
+	}
+	return 0
+}
+
+// matchAt tries every extract pattern at position start.
+func matchAt(text string, start int) (Match, bool) {
+	for _, p := range extractPatterns {
+		end := start + len(p.pattern)
+		if end > len(text) || !matchesPattern(text[start:end], p.pattern) {
+			continue
+		}
+		if continuesAfter(text, end) {
+			continue
+		}
+
+		m := newMatch(text, start, end, p.cnpj)
+		if p.requireValid && !m.Valid {
+			continue
+		}
+		return m, true
+	}
+	return Match{}, false
+}
+
+// newMatch builds a Match for text[start:end], validating the candidate.
+func newMatch(text string, start, end int, cnpj bool) Match {
+	m := Match{
+		Start: start,
+		End:   end,
+		Text:  text[start:end],
+	}
+
+	raw := cleanString(m.Text)
+	m.Raw = raw
+
+	if cnpj {
+		_, err := NewCnpj(raw)
+		m.Kind = cnpjKind(raw)
+		m.Formatted = CNPJ(raw).String()
+		m.Valid = err == nil
+	} else {
+		_, err := NewCpf(raw)
+		m.Kind = KindCPF
+		m.Formatted = CPF(raw).String()
+		m.Valid = err == nil
+	}
+
+	return m
+}
+
+// matchesPattern reports whether s fits pattern (see extractPattern for the syntax).
+func matchesPattern(s, pattern string) bool {
+	if len(s) != len(pattern) {
+		return false
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		ch := s[i]
+		switch pattern[i] {
+		case 'D':
+			if ch < '0' || ch > '9' {
+				return false
+			}
+		case 'A':
+			if !isAlphanumericByte(ch) {
+				return false
+			}
+		default:
+			if ch != pattern[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isSeparatorByte reports whether ch is one of the separators used by formatted documents.
+func isSeparatorByte(ch byte) bool {
+	return ch == '.' || ch == '-' || ch == '/'
+}
+
+// isAlphanumericByte reports whether ch is an ASCII letter (any case) or digit.
+func isAlphanumericByte(ch byte) bool {
+	return (ch >= '0' && ch <= '9') || (ch >= 'A' && ch <= 'Z') || (ch >= 'a' && ch <= 'z')
+}
@@ -0,0 +1,196 @@
+package cpfcnpj
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test document extraction from free text
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []Match
+	}{
+		{
+			name: "Formatted CPF in a sentence",
+			text: "Meu CPF é 716.566.867-59, obrigado.",
+			expected: []Match{{
+				Start: 11, End: 25, Kind: KindCPF, Text: "716.566.867-59",
+				Raw: "71656686759", Formatted: "716.566.867-59", Valid: true,
+			}},
+		},
+		{
+			name: "Unformatted CPF and CNPJ",
+			text: "cpf=71656686759 cnpj=22796729000159",
+			expected: []Match{
+				{
+					Start: 4, End: 15, Kind: KindCPF, Text: "71656686759",
+					Raw: "71656686759", Formatted: "716.566.867-59", Valid: true,
+				},
+				{
+					Start: 21, End: 35, Kind: KindCNPJNumeric, Text: "22796729000159",
+					Raw: "22796729000159", Formatted: "22.796.729/0001-59", Valid: true,
+				},
+			},
+		},
+		{
+			name: "Formatted alphanumeric CNPJ",
+			text: "Empresa (12.ABC.345/01DE-35)",
+			expected: []Match{{
+				Start: 9, End: 27, Kind: KindCNPJAlphanumeric, Text: "12.ABC.345/01DE-35",
+				Raw: "12ABC34501DE35", Formatted: "12.ABC.345/01DE-35", Valid: true,
+			}},
+		},
+		{
+			name: "Unformatted alphanumeric CNPJ",
+			text: "id:12ABC34501DE35\n",
+			expected: []Match{{
+				Start: 3, End: 17, Kind: KindCNPJAlphanumeric, Text: "12ABC34501DE35",
+				Raw: "12ABC34501DE35", Formatted: "12.ABC.345/01DE-35", Valid: true,
+			}},
+		},
+		{
+			name: "Lowercase alphanumeric CNPJ",
+			text: "cnpj 12.abc.345/01de-35 ou 12abc34501de35",
+			expected: []Match{
+				{
+					Start: 5, End: 23, Kind: KindCNPJAlphanumeric, Text: "12.abc.345/01de-35",
+					Raw: "12ABC34501DE35", Formatted: "12.ABC.345/01DE-35", Valid: true,
+				},
+				{
+					Start: 27, End: 41, Kind: KindCNPJAlphanumeric, Text: "12abc34501de35",
+					Raw: "12ABC34501DE35", Formatted: "12.ABC.345/01DE-35", Valid: true,
+				},
+			},
+		},
+		{
+			name: "Invalid check digits are reported",
+			text: "CPF 716.566.867-58 e CNPJ 22796729000158",
+			expected: []Match{
+				{
+					Start: 4, End: 18, Kind: KindCPF, Text: "716.566.867-58",
+					Raw: "71656686758", Formatted: "716.566.867-58", Valid: false,
+				},
+				{
+					Start: 26, End: 40, Kind: KindCNPJNumeric, Text: "22796729000158",
+					Raw: "22796729000158", Formatted: "22.796.729/0001-58", Valid: false,
+				},
+			},
+		},
+		{
+			name:     "Invalid unformatted alphanumeric is ignored",
+			text:     "ABCDEFGHIJKL12 and 12ABC34501DE99",
+			expected: nil,
+		},
+		{
+			name:     "NF-e access key is not split",
+			text:     "chave 35190722796729000159550010000001231000001234",
+			expected: nil,
+		},
+		{
+			name:     "Longer digit runs are ignored",
+			text:     "pedido 716566867590 e 171656686759",
+			expected: nil,
+		},
+		{
+			name:     "Separated digit runs are ignored",
+			text:     "12.345.678.901-23 e 9-716.566.867-59 e a/22.796.729/0001-59",
+			expected: nil,
+		},
+		{
+			name:     "Phone numbers are ignored",
+			text:     "ligue +55 (11) 98765-4321 ou +5511987654321",
+			expected: nil,
+		},
+		{
+			name:     "Letters glued to digits are ignored",
+			text:     "abc71656686759 71656686759x",
+			expected: nil,
+		},
+		{
+			name:     "Empty text",
+			text:     "",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Extract(tt.text)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("Extract(%q) returned %d matches, want %d: %+v", tt.text, len(got), len(tt.expected), got)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("Extract(%q)[%d] = %+v, want %+v", tt.text, i, got[i], tt.expected[i])
+				}
+				if tt.text[got[i].Start:got[i].End] != got[i].Text {
+					t.Errorf("Extract(%q)[%d] offsets do not match text", tt.text, i)
+				}
+			}
+		})
+	}
+}
+
+// Test extraction at text boundaries and with mixed content
+func TestExtract_Boundaries(t *testing.T) {
+	tests := []struct {
+		name          string
+		text          string
+		expectedTexts []string
+	}{
+		{"Whole text is a CPF", "71656686759", []string{"71656686759"}},
+		{"Documents separated by commas", "71656686759,22796729000159", []string{"71656686759", "22796729000159"}},
+		{"Documents in quotes", `"716.566.867-59"`, []string{"716.566.867-59"}},
+		{"Unicode around document", "ção 716.566.867-59 ção", []string{"716.566.867-59"}},
+		{"Document after longer run", "7165668675912 71656686759", []string{"71656686759"}},
+		{"Run continued after a separator", "x 71656686759-12 y 716.566.867-59.123 z", nil},
+		{"Branch list after a CNPJ", "22.796.729/0001-59/0002", nil},
+		{"Document before a lone separator", "716.566.867-59. e 71656686759-", []string{"716.566.867-59", "71656686759"}},
+		{"Document after a lone separator", "-716.566.867-59 (/22796729000159)",
+			[]string{"716.566.867-59", "22796729000159"}},
+		{"Tabs and newlines", "\t22.796.729/0001-59\n12ABC34501DE35", []string{"22.796.729/0001-59", "12ABC34501DE35"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Extract(tt.text)
+			var texts []string
+			for _, m := range got {
+				texts = append(texts, m.Text)
+			}
+			if strings.Join(texts, "|") != strings.Join(tt.expectedTexts, "|") {
+				t.Errorf("Extract(%q) = %v, want %v", tt.text, texts, tt.expectedTexts)
+			}
+		})
+	}
+}
+
+// Test converting matches back into documents
+func TestMatchDocument(t *testing.T) {
+	matches := Extract("716.566.867-59 12ABC34501DE35 716.566.867-58")
+	if len(matches) != 3 {
+		t.Fatalf("Extract returned %d matches, want 3", len(matches))
+	}
+
+	if doc, ok := matches[0].Document().(CPF); !ok || doc != "71656686759" {
+		t.Errorf("matches[0].Document() = %v, want CPF 71656686759", matches[0].Document())
+	}
+	if doc, ok := matches[1].Document().(CNPJ); !ok || doc != "12ABC34501DE35" {
+		t.Errorf("matches[1].Document() = %v, want CNPJ 12ABC34501DE35", matches[1].Document())
+	}
+	if doc := matches[2].Document(); doc != nil {
+		t.Errorf("invalid match Document() = %v, want nil", doc)
+	}
+}
+
+// Benchmark extraction over a log-like line
+func BenchmarkExtract(b *testing.B) {
+	text := strings.Repeat("2024-01-01 INFO user=716.566.867-59 company=12ABC34501DE35 phone=11987654321 ", 20)
+	b.ReportAllocs()
+	b.SetBytes(int64(len(text)))
+	for i := 0; i < b.N; i++ {
+		_ = Extract(text)
+	}
+}
@@ -0,0 +1,85 @@
+package cpfcnpj
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FiscalRegion is the Receita Federal fiscal region that issued a CPF,
+// identified by the CPF's 9th digit (0-9).
+type FiscalRegion int
+
+// FiscalRegionUnknown is returned when the region cannot be determined.
+const FiscalRegionUnknown FiscalRegion = -1
+
+// fiscalRegionUFs lists the states (UFs) covered by each fiscal region, indexed by the 9th CPF digit.
+var fiscalRegionUFs = [10][]string{
+	0: {"RS"},
+	1: {"DF", "GO", "MS", "MT", "TO"},
+	2: {"AC", "AM", "AP", "PA", "RO", "RR"},
+	3: {"CE", "MA", "PI"},
+	4: {"AL", "PB", "PE", "RN"},
+	5: {"BA", "SE"},
+	6: {"MG"},
+	7: {"ES", "RJ"},
+	8: {"SP"},
+	9: {"PR", "SC"},
+}
+
+// FiscalRegion returns the fiscal region that issued the CPF, taken from its 9th digit.
+// Returns FiscalRegionUnknown if the CPF is not 11 digits long.
+func (c CPF) FiscalRegion() FiscalRegion {
+	if len(c) != CPFLength || c[8] < '0' || c[8] > '9' {
+		return FiscalRegionUnknown
+	}
+	return FiscalRegion(c[8] - '0')
+}
+
+// FiscalRegionOf returns the fiscal region covering the given state abbreviation (e.g. "sp", "RJ").
+// The second result is false if uf is not a Brazilian state.
+func FiscalRegionOf(uf string) (FiscalRegion, bool) {
+	uf = strings.ToUpper(strings.TrimSpace(uf))
+	for digit, ufs := range fiscalRegionUFs {
+		if slices.Contains(ufs, uf) {
+			return FiscalRegion(digit), true
+		}
+	}
+	return FiscalRegionUnknown, false
+}
+
+// IsValid reports whether the region is one of the ten fiscal regions (0-9).
+func (r FiscalRegion) IsValid() bool {
+	return r >= 0 && int(r) < len(fiscalRegionUFs)
+}
+
+// Digit returns the CPF digit identifying the region, or -1 for FiscalRegionUnknown.
+func (r FiscalRegion) Digit() int {
+	if !r.IsValid() {
+		return -1
+	}
+	return int(r)
+}
+
+// UFs returns the states covered by the region, sorted alphabetically.
+// The returned slice is a copy and may be modified by the caller.
+func (r FiscalRegion) UFs() []string {
+	if !r.IsValid() {
+		return nil
+	}
+	return slices.Clone(fiscalRegionUFs[r])
+}
+
+// Contains reports whether the region covers the given state abbreviation.
+func (r FiscalRegion) Contains(uf string) bool {
+	region, ok := FiscalRegionOf(uf)
+	return ok && region == r
+}
+
+// String returns the region digit followed by its states, e.g. "7 (ES, RJ)".
+func (r FiscalRegion) String() string {
+	if !r.IsValid() {
+		return "unknown"
+	}
+	return strconv.Itoa(int(r)) + " (" + strings.Join(fiscalRegionUFs[r], ", ") + ")"
+}
@@ -0,0 +1,134 @@
+package cpfcnpj
+
+import (
+	"slices"
+	"testing"
+)
+
+// Test CPF fiscal region lookup from the 9th digit
+func TestCPFFiscalRegion(t *testing.T) {
+	tests := []struct {
+		name        string
+		cpf         CPF
+		expected    FiscalRegion
+		expectedUFs []string
+	}{
+		{"Region 7 RJ/ES", "71656686759", 7, []string{"ES", "RJ"}},
+		{"Region 7 formatted source", "64844696793", 7, []string{"ES", "RJ"}},
+		{"Region 0 RS", "03167158085", 0, []string{"RS"}},
+		{"Region 8 SP", "62641322846", 8, []string{"SP"}},
+		{"Wrong length", "123", FiscalRegionUnknown, nil},
+		{"Zero value", "", FiscalRegionUnknown, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			region := tt.cpf.FiscalRegion()
+			if region != tt.expected {
+				t.Fatalf("CPF(%q).FiscalRegion() = %v, want %v", tt.cpf, region, tt.expected)
+			}
+			if got := region.UFs(); !slices.Equal(got, tt.expectedUFs) {
+				t.Errorf("FiscalRegion(%d).UFs() = %v, want %v", region, got, tt.expectedUFs)
+			}
+		})
+	}
+}
+
+// Test reverse lookup from state to fiscal region
+func TestFiscalRegionOf(t *testing.T) {
+	tests := []struct {
+		uf       string
+		expected FiscalRegion
+		ok       bool
+	}{
+		{"SP", 8, true},
+		{"sp", 8, true},
+		{" RJ ", 7, true},
+		{"ES", 7, true},
+		{"RS", 0, true},
+		{"DF", 1, true},
+		{"TO", 1, true},
+		{"AM", 2, true},
+		{"PI", 3, true},
+		{"PE", 4, true},
+		{"SE", 5, true},
+		{"MG", 6, true},
+		{"SC", 9, true},
+		{"XX", FiscalRegionUnknown, false},
+		{"", FiscalRegionUnknown, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.uf, func(t *testing.T) {
+			region, ok := FiscalRegionOf(tt.uf)
+			if region != tt.expected || ok != tt.ok {
+				t.Errorf("FiscalRegionOf(%q) = %v, %v, want %v, %v", tt.uf, region, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+// Test that every state belongs to exactly one region
+func TestFiscalRegion_AllStatesCovered(t *testing.T) {
+	seen := make(map[string]FiscalRegion)
+	for digit := FiscalRegion(0); digit <= 9; digit++ {
+		for _, uf := range digit.UFs() {
+			if previous, ok := seen[uf]; ok {
+				t.Errorf("UF %s listed in regions %d and %d", uf, previous, digit)
+			}
+			seen[uf] = digit
+		}
+	}
+
+	// 26 states plus the Federal District
+	if len(seen) != 27 {
+		t.Errorf("fiscal regions cover %d UFs, want 27", len(seen))
+	}
+}
+
+// Test FiscalRegion helper methods
+func TestFiscalRegionMethods(t *testing.T) {
+	tests := []struct {
+		name           string
+		region         FiscalRegion
+		expectedValid  bool
+		expectedDigit  int
+		expectedString string
+	}{
+		{"Region 0", 0, true, 0, "0 (RS)"},
+		{"Region 7", 7, true, 7, "7 (ES, RJ)"},
+		{"Region 1", 1, true, 1, "1 (DF, GO, MS, MT, TO)"},
+		{"Unknown", FiscalRegionUnknown, false, -1, "unknown"},
+		{"Out of range", 10, false, -1, "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.region.IsValid(); got != tt.expectedValid {
+				t.Errorf("IsValid() = %v, want %v", got, tt.expectedValid)
+			}
+			if got := tt.region.Digit(); got != tt.expectedDigit {
+				t.Errorf("Digit() = %d, want %d", got, tt.expectedDigit)
+			}
+			if got := tt.region.String(); got != tt.expectedString {
+				t.Errorf("String() = %q, want %q", got, tt.expectedString)
+			}
+		})
+	}
+
+	// Comparing the issuing region with a declared address
+	cpf := CPF("62641322846")
+	if !cpf.FiscalRegion().Contains("sp") {
+		t.Errorf("region %v should contain SP", cpf.FiscalRegion())
+	}
+	if cpf.FiscalRegion().Contains("RJ") {
+		t.Errorf("region %v should not contain RJ", cpf.FiscalRegion())
+	}
+
+	// UFs returns a copy
+	ufs := FiscalRegion(8).UFs()
+	ufs[0] = "XX"
+	if FiscalRegion(8).UFs()[0] != "SP" {
+		t.Error("UFs() exposed internal table")
+	}
+}
@@ -0,0 +1,230 @@
+package cpfcnpj
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrInvalidCipherKey is returned by NewDocumentCipher for keys that are not valid AES keys.
+var ErrInvalidCipherKey = errors.New("invalid document cipher key")
+
+// Radixes of the document alphabets
+const (
+	numericRadix      = len(digitChars)
+	alphanumericRadix = len(alphanumericChars)
+)
+
+// DocumentCipher is a format-preserving cipher (FF1, NIST SP 800-38G, with AES) that encrypts a
+// document into another valid document of the same kind. Only the base is encrypted; check digits
+// are recomputed, so ciphertexts pass NewCpf and NewCnpj. It is meant for masking non-production
+// copies of data, where test environments must keep working with real validators.
+//
+// The tweak is public and may be empty; using a different tweak per dataset or column makes the
+// same document encrypt differently in each. A DocumentCipher is safe for concurrent use.
+type DocumentCipher struct {
+	block cipher.Block
+}
+
+// NewDocumentCipher returns a DocumentCipher using key, which must be 16, 24 or 32 bytes (AES-128, -192 or -256).
+func NewDocumentCipher(key []byte) (*DocumentCipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCipherKey, err)
+	}
+	return &DocumentCipher{block: block}, nil
+}
+
+// EncryptCpf encrypts cpf into another valid CPF.
+func (c *DocumentCipher) EncryptCpf(cpf CPF, tweak []byte) (CPF, error) {
+	return c.cryptCpf(cpf, tweak, false)
+}
+
+// DecryptCpf reverses EncryptCpf with the same key and tweak.
+func (c *DocumentCipher) DecryptCpf(cpf CPF, tweak []byte) (CPF, error) {
+	return c.cryptCpf(cpf, tweak, true)
+}
+
+// EncryptCnpj encrypts cnpj into another valid CNPJ of the same kind: numeric CNPJs stay numeric
+// and alphanumeric CNPJs stay alphanumeric.
+func (c *DocumentCipher) EncryptCnpj(cnpj CNPJ, tweak []byte) (CNPJ, error) {
+	return c.cryptCnpj(cnpj, tweak, false)
+}
+
+// DecryptCnpj reverses EncryptCnpj with the same key and tweak.
+func (c *DocumentCipher) DecryptCnpj(cnpj CNPJ, tweak []byte) (CNPJ, error) {
+	return c.cryptCnpj(cnpj, tweak, true)
+}
+
+func (c *DocumentCipher) cryptCpf(cpf CPF, tweak []byte, decrypt bool) (CPF, error) {
+	valid, err := NewCpf(string(cpf))
+	if err != nil {
+		return "", err
+	}
+
+	base := c.cycleWalk(valid.Raw()[:CPFBaseLength], numericRadix, tweak, decrypt, func(base string) bool {
+		_, err := CompleteCpf(base)
+		return err == nil
+	})
+	return CompleteCpf(base)
+}
+
+func (c *DocumentCipher) cryptCnpj(cnpj CNPJ, tweak []byte, decrypt bool) (CNPJ, error) {
+	valid, err := NewCnpj(string(cnpj))
+	if err != nil {
+		return "", err
+	}
+
+	kind := valid.Kind()
+	radix := numericRadix
+	if kind == KindCNPJAlphanumeric {
+		radix = alphanumericRadix
+	}
+
+	base := c.cycleWalk(valid.Raw()[:CNPJBaseLength], radix, tweak, decrypt, func(base string) bool {
+		_, err := CompleteCnpj(base)
+		return err == nil && cnpjKind(base) == kind
+	})
+	return CompleteCnpj(base)
+}
+
+// cycleWalk applies FF1 to base until the result satisfies valid. Since base itself is valid,
+// this is a permutation of the valid bases, and decrypting walks the same cycle backwards.
+func (c *DocumentCipher) cycleWalk(base string, radix int, tweak []byte, decrypt bool, valid func(string) bool) string {
+	numerals := make([]byte, len(base))
+	for i := 0; i < len(base); i++ {
+		numerals[i] = byte(strings.IndexByte(alphanumericChars, base[i]))
+	}
+
+	for {
+		numerals = ff1(c.block, radix, numerals, tweak, decrypt)
+
+		result := make([]byte, len(numerals))
+		for i, n := range numerals {
+			result[i] = alphanumericChars[n]
+		}
+		if valid(string(result)) {
+			return string(result)
+		}
+	}
+}
+
+// ff1 encrypts or decrypts the numeral string x (values 0 to radix-1) with FF1 as specified
+// in NIST SP 800-38G, section 6.2.
+func ff1(block cipher.Block, radix int, x []byte, tweak []byte, decrypt bool) []byte {
+	n := len(x)
+	u := n / 2
+	v := n - u
+	t := len(tweak)
+
+	a := append([]byte(nil), x[:u]...)
+	b := append([]byte(nil), x[u:]...)
+
+	bigRadix := big.NewInt(int64(radix))
+	modU := new(big.Int).Exp(bigRadix, big.NewInt(int64(u)), nil)
+	modV := new(big.Int).Exp(bigRadix, big.NewInt(int64(v)), nil)
+
+	// Steps 3-4: b = ceil(ceil(v * log2(radix)) / 8), the byte length of the largest v-numeral value
+	byteLen := (new(big.Int).Sub(modV, big.NewInt(1)).BitLen() + 7) / 8
+	d := 4*((byteLen+3)/4) + 4
+
+	// Step 5
+	p := make([]byte, aes.BlockSize)
+	p[0], p[1], p[2] = 1, 2, 1
+	p[3], p[4], p[5] = byte(radix>>16), byte(radix>>8), byte(radix)
+	p[6] = 10
+	p[7] = byte(u)
+	binary.BigEndian.PutUint32(p[8:], uint32(n))
+	binary.BigEndian.PutUint32(p[12:], uint32(t))
+
+	pad := (aes.BlockSize - (t+byteLen+1)%aes.BlockSize) % aes.BlockSize
+	q := make([]byte, t+pad+1+byteLen)
+	copy(q, tweak)
+
+	// Step 6
+	for round := 0; round < 10; round++ {
+		i := round
+		half := b
+		if decrypt {
+			i = 9 - round
+			half = a
+		}
+
+		q[t+pad] = byte(i)
+		numeralsToInt(half, bigRadix).FillBytes(q[t+pad+1:])
+
+		y := new(big.Int).SetBytes(ff1Expand(block, ff1PRF(block, p, q), d))
+
+		m, mod := u, modU
+		if i%2 == 1 {
+			m, mod = v, modV
+		}
+
+		if decrypt {
+			y.Sub(numeralsToInt(b, bigRadix), y)
+			b, a = a, intToNumerals(y.Mod(y, mod), bigRadix, m)
+		} else {
+			y.Add(numeralsToInt(a, bigRadix), y)
+			a, b = b, intToNumerals(y.Mod(y, mod), bigRadix, m)
+		}
+	}
+
+	return append(a, b...)
+}
+
+// ff1PRF is the CBC-MAC of p || q with a zero IV.
+func ff1PRF(block cipher.Block, p, q []byte) []byte {
+	y := make([]byte, aes.BlockSize)
+	for _, data := range [][]byte{p, q} {
+		for len(data) > 0 {
+			blk := data[:aes.BlockSize]
+			for j := range y {
+				y[j] ^= blk[j]
+			}
+			block.Encrypt(y, y)
+			data = data[aes.BlockSize:]
+		}
+	}
+	return y
+}
+
+// ff1Expand returns the first d bytes of r || CIPH(r ^ [1]^16) || CIPH(r ^ [2]^16) || ...
+func ff1Expand(block cipher.Block, r []byte, d int) []byte {
+	s := append([]byte(nil), r...)
+	for j := uint64(1); len(s) < d; j++ {
+		blk := make([]byte, aes.BlockSize)
+		binary.BigEndian.PutUint64(blk[8:], j)
+		for k := range blk {
+			blk[k] ^= r[k]
+		}
+		block.Encrypt(blk, blk)
+		s = append(s, blk...)
+	}
+	return s[:d]
+}
+
+// numeralsToInt returns the value of a numeral string, most significant numeral first.
+func numeralsToInt(x []byte, radix *big.Int) *big.Int {
+	result := new(big.Int)
+	for _, n := range x {
+		result.Mul(result, radix)
+		result.Add(result, big.NewInt(int64(n)))
+	}
+	return result
+}
+
+// intToNumerals returns the m-numeral representation of x, most significant numeral first.
+func intToNumerals(x, radix *big.Int, m int) []byte {
+	result := make([]byte, m)
+	value := new(big.Int).Set(x)
+	rem := new(big.Int)
+	for i := m - 1; i >= 0; i-- {
+		value.DivMod(value, radix, rem)
+		result[i] = byte(rem.Int64())
+	}
+	return result
+}
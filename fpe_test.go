@@ -0,0 +1,204 @@
+package cpfcnpj
+
+import (
+	"bytes"
+	"crypto/aes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var testCipherKey, _ = hex.DecodeString("2B7E151628AED2A6ABF7158809CF4F3C")
+
+// Test FF1 against the NIST SP 800-38G AES-128 sample vectors
+func TestFF1_NISTVectors(t *testing.T) {
+	tests := []struct {
+		name       string
+		radix      int
+		tweak      string
+		plaintext  string
+		ciphertext string
+	}{
+		{"Sample 1", 10, "", "0123456789", "2433477484"},
+		{"Sample 2", 10, "39383736353433323130", "0123456789", "6124200773"},
+		{"Sample 3", 36, "3737373770717273373737", "0123456789abcdefghi", "a9tv40mll9kdu509eum"},
+	}
+
+	block, err := aes.NewCipher(testCipherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const alphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+	toNumerals := func(s string) []byte {
+		x := make([]byte, len(s))
+		for i := range s {
+			x[i] = byte(strings.IndexByte(alphabet, s[i]))
+		}
+		return x
+	}
+	toString := func(x []byte) string {
+		var s strings.Builder
+		for _, n := range x {
+			s.WriteByte(alphabet[n])
+		}
+		return s.String()
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tweak, _ := hex.DecodeString(tt.tweak)
+
+			got := toString(ff1(block, tt.radix, toNumerals(tt.plaintext), tweak, false))
+			if got != tt.ciphertext {
+				t.Errorf("encrypt = %q, want %q", got, tt.ciphertext)
+			}
+
+			got = toString(ff1(block, tt.radix, toNumerals(tt.ciphertext), tweak, true))
+			if got != tt.plaintext {
+				t.Errorf("decrypt = %q, want %q", got, tt.plaintext)
+			}
+		})
+	}
+}
+
+// Test that encrypted CPFs are valid and decrypt back
+func TestDocumentCipher_Cpf(t *testing.T) {
+	c, err := NewDocumentCipher(testCipherKey)
+	if err != nil {
+		t.Fatalf("NewDocumentCipher() unexpected error: %v", err)
+	}
+
+	for _, tweak := range [][]byte{nil, []byte("clients.cpf")} {
+		for _, validCPF := range validCPFs {
+			enc, err := c.EncryptCpf(CPF(validCPF), tweak)
+			if err != nil {
+				t.Fatalf("EncryptCpf(%q) unexpected error: %v", validCPF, err)
+			}
+			if _, err := NewCpf(enc.Raw()); err != nil {
+				t.Errorf("EncryptCpf(%q) = %q does not pass NewCpf: %v", validCPF, enc, err)
+			}
+			if enc.Raw() == validCPF {
+				t.Errorf("EncryptCpf(%q) returned the plaintext", validCPF)
+			}
+
+			dec, err := c.DecryptCpf(enc, tweak)
+			if err != nil || dec.Raw() != validCPF {
+				t.Errorf("DecryptCpf(%q) = %q, %v, want %q", enc, dec, err, validCPF)
+			}
+		}
+	}
+}
+
+// Test that encrypted CNPJs keep their kind and decrypt back
+func TestDocumentCipher_Cnpj(t *testing.T) {
+	c, _ := NewDocumentCipher(testCipherKey)
+
+	tests := []struct {
+		name  string
+		input CNPJ
+	}{
+		{"Numeric", "22796729000159"},
+		{"Numeric branch", "11222333000262"},
+		{"Alphanumeric", "12ABC34501DE35"},
+		{"Formatted alphanumeric", "12.ABC.345/01DE-35"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original, err := NewCnpj(string(tt.input))
+			if err != nil {
+				t.Fatalf("NewCnpj(%q) unexpected error: %v", tt.input, err)
+			}
+
+			enc, err := c.EncryptCnpj(tt.input, []byte("tweak"))
+			if err != nil {
+				t.Fatalf("EncryptCnpj(%q) unexpected error: %v", tt.input, err)
+			}
+			if _, err := NewCnpj(enc.Raw()); err != nil {
+				t.Errorf("EncryptCnpj(%q) = %q does not pass NewCnpj: %v", tt.input, enc, err)
+			}
+			if enc.Kind() != original.Kind() {
+				t.Errorf("EncryptCnpj(%q) kind = %v, want %v", tt.input, enc.Kind(), original.Kind())
+			}
+
+			dec, err := c.DecryptCnpj(enc, []byte("tweak"))
+			if err != nil || dec != original {
+				t.Errorf("DecryptCnpj(%q) = %q, %v, want %q", enc, dec, err, original)
+			}
+		})
+	}
+}
+
+// Test round trips over many generated documents, which exercises cycle walking
+func TestDocumentCipher_RoundTripGenerated(t *testing.T) {
+	c, _ := NewDocumentCipher(bytes.Repeat([]byte{7}, 32))
+
+	for i := 0; i < 100; i++ {
+		cpf, _ := GenerateCpf()
+		enc, err := c.EncryptCpf(cpf, nil)
+		if err != nil {
+			t.Fatalf("EncryptCpf(%q) unexpected error: %v", cpf, err)
+		}
+		if dec, _ := c.DecryptCpf(enc, nil); dec != cpf {
+			t.Fatalf("DecryptCpf(EncryptCpf(%q)) = %q", cpf, dec)
+		}
+
+		cnpj, _ := GenerateCnpj(WithAlphanumeric())
+		encCnpj, err := c.EncryptCnpj(cnpj, nil)
+		if err != nil {
+			t.Fatalf("EncryptCnpj(%q) unexpected error: %v", cnpj, err)
+		}
+		if !encCnpj.IsAlphanumeric() {
+			t.Fatalf("EncryptCnpj(%q) = %q is not alphanumeric", cnpj, encCnpj)
+		}
+		if dec, _ := c.DecryptCnpj(encCnpj, nil); dec != cnpj {
+			t.Fatalf("DecryptCnpj(EncryptCnpj(%q)) = %q", cnpj, dec)
+		}
+	}
+}
+
+// Test that keys and tweaks change the ciphertext
+func TestDocumentCipher_KeyAndTweak(t *testing.T) {
+	c1, _ := NewDocumentCipher(testCipherKey)
+	c2, _ := NewDocumentCipher(bytes.Repeat([]byte{1}, 16))
+
+	a, _ := c1.EncryptCpf("71656686759", []byte("a"))
+	b, _ := c1.EncryptCpf("71656686759", []byte("b"))
+	k, _ := c2.EncryptCpf("71656686759", []byte("a"))
+
+	if a == b {
+		t.Error("different tweaks produced the same ciphertext")
+	}
+	if a == k {
+		t.Error("different keys produced the same ciphertext")
+	}
+	if again, _ := c1.EncryptCpf("716.566.867-59", []byte("a")); again != a {
+		t.Errorf("encryption not deterministic: %q != %q", again, a)
+	}
+}
+
+// Test invalid keys and documents
+func TestDocumentCipher_Errors(t *testing.T) {
+	if _, err := NewDocumentCipher([]byte("short")); !errors.Is(err, ErrInvalidCipherKey) {
+		t.Errorf("NewDocumentCipher(short key) error = %v, want %v", err, ErrInvalidCipherKey)
+	}
+
+	c, _ := NewDocumentCipher(testCipherKey)
+	if _, err := c.EncryptCpf("71656686758", nil); !errors.Is(err, ErrCPFInvalidChecksum) {
+		t.Errorf("EncryptCpf(invalid) error = %v, want %v", err, ErrCPFInvalidChecksum)
+	}
+	if _, err := c.DecryptCnpj("12ABC34501DE99", nil); !errors.Is(err, ErrCNPJInvalidChecksum) {
+		t.Errorf("DecryptCnpj(invalid) error = %v, want %v", err, ErrCNPJInvalidChecksum)
+	}
+}
+
+// Benchmark CPF encryption
+func BenchmarkDocumentCipher_EncryptCpf(b *testing.B) {
+	c, _ := NewDocumentCipher(testCipherKey)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = c.EncryptCpf("71656686759", nil)
+	}
+}
@@ -0,0 +1,159 @@
+package cpfcnpj
+
+import (
+	crand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math/rand/v2"
+)
+
+// Character sets used by the generator
+const (
+	digitChars        = "0123456789"
+	alphanumericChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+)
+
+// GenerateOption configures GenerateCpf and GenerateCnpj.
+type GenerateOption func(*generateConfig)
+
+type generateConfig struct {
+	rng          *rand.Rand
+	region       FiscalRegion // FiscalRegionUnknown means random
+	alphanumeric bool
+	branch       int
+}
+
+// WithRand makes the generator draw from r, so a seeded source produces reproducible documents:
+//
+//	r := rand.New(rand.NewPCG(1, 2))
+//	cpf, _ := cpfcnpj.GenerateCpf(cpfcnpj.WithRand(r))
+//
+// A *rand.Rand is not safe for concurrent use; share it only between calls made from one goroutine.
+func WithRand(r *rand.Rand) GenerateOption {
+	return func(c *generateConfig) {
+		c.rng = r
+	}
+}
+
+// WithCryptoRand makes the generator draw from crypto/rand instead of the default math/rand/v2 source.
+func WithCryptoRand() GenerateOption {
+	return func(c *generateConfig) {
+		c.rng = rand.New(cryptoSource{})
+	}
+}
+
+// WithFiscalRegion fixes the 9th CPF digit, which identifies the issuing fiscal region (0-9).
+// It has no effect on GenerateCnpj.
+func WithFiscalRegion(region FiscalRegion) GenerateOption {
+	return func(c *generateConfig) {
+		c.region = region
+	}
+}
+
+// WithAlphanumeric makes GenerateCnpj produce a CNPJ Alfanumérico whose root contains at least one letter.
+// It has no effect on GenerateCpf.
+func WithAlphanumeric() GenerateOption {
+	return func(c *generateConfig) {
+		c.alphanumeric = true
+	}
+}
+
+// WithBranch sets the CNPJ branch order (1-9999). GenerateCnpj produces a headquarters
+// CNPJ (branch 0001) unless this option is given. It has no effect on GenerateCpf.
+func WithBranch(order int) GenerateOption {
+	return func(c *generateConfig) {
+		c.branch = order
+	}
+}
+
+// GenerateCpf returns a random valid CPF.
+// Generated values never have all digits the same and always pass NewCpf.
+func GenerateCpf(opts ...GenerateOption) (CPF, error) {
+	cfg, err := newGenerateConfig(opts)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		base := make([]byte, CPFBaseLength)
+		for i := range base {
+			base[i] = cfg.pick(digitChars)
+		}
+		if cfg.region != FiscalRegionUnknown {
+			base[CPFBaseLength-1] = byte('0' + cfg.region)
+		}
+
+		// Same-digit bases produce checksum-valid but rejected CPFs, draw again
+		if isSameCharacter(string(base)) {
+			continue
+		}
+
+		return CompleteCpf(string(base))
+	}
+}
+
+// GenerateCnpj returns a random valid CNPJ, numeric by default.
+// Generated values never have all characters the same and always pass NewCnpj.
+func GenerateCnpj(opts ...GenerateOption) (CNPJ, error) {
+	cfg, err := newGenerateConfig(opts)
+	if err != nil {
+		return "", err
+	}
+
+	chars := digitChars
+	if cfg.alphanumeric {
+		chars = alphanumericChars
+	}
+
+	for {
+		root := make([]byte, CNPJRootLength)
+		for i := range root {
+			root[i] = cfg.pick(chars)
+		}
+
+		// Alphanumeric requests must not fall back to a purely numeric root
+		if cfg.alphanumeric && !hasLetter(string(root)) {
+			continue
+		}
+
+		return CNPJRoot(root).Branch(cfg.branch)
+	}
+}
+
+func newGenerateConfig(opts []GenerateOption) (*generateConfig, error) {
+	cfg := &generateConfig{
+		region: FiscalRegionUnknown,
+		branch: headquartersBranch,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.region != FiscalRegionUnknown && !cfg.region.IsValid() {
+		return nil, fmt.Errorf("fiscal region must be a digit between 0 and 9, got %d: %w", cfg.region,
+			ErrInvalidOption)
+	}
+	if cfg.branch < headquartersBranch || cfg.branch > maxBranch {
+		return nil, fmt.Errorf("branch order must be between 1 and %d, got %d: %w", maxBranch, cfg.branch,
+			ErrInvalidOption)
+	}
+
+	return cfg, nil
+}
+
+// pick returns a random character from chars.
+func (c *generateConfig) pick(chars string) byte {
+	if c.rng != nil {
+		return chars[c.rng.IntN(len(chars))]
+	}
+	return chars[rand.IntN(len(chars))]
+}
+
+// cryptoSource is a rand.Source backed by crypto/rand.
+type cryptoSource struct{}
+
+func (cryptoSource) Uint64() uint64 {
+	var b [8]byte
+	_, _ = crand.Read(b[:]) // crypto/rand.Read never returns an error since Go 1.24
+	return binary.LittleEndian.Uint64(b[:])
+}
@@ -0,0 +1,138 @@
+package cpfcnpj
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+)
+
+// Test that generated CPFs always pass NewCpf
+func TestGenerateCpf(t *testing.T) {
+	tests := []struct {
+		name   string
+		opts   []GenerateOption
+		region FiscalRegion
+	}{
+		{"Default source", nil, FiscalRegionUnknown},
+		{"Seeded source", []GenerateOption{WithRand(rand.New(rand.NewPCG(1, 2)))}, FiscalRegionUnknown},
+		{"Crypto source", []GenerateOption{WithCryptoRand()}, FiscalRegionUnknown},
+		{"Fiscal region SP", []GenerateOption{WithFiscalRegion(8)}, 8},
+		{"Fiscal region RS", []GenerateOption{WithFiscalRegion(0)}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 200; i++ {
+				cpf, err := GenerateCpf(tt.opts...)
+				if err != nil {
+					t.Fatalf("GenerateCpf() unexpected error: %v", err)
+				}
+				if _, err := NewCpf(cpf.Raw()); err != nil {
+					t.Fatalf("GenerateCpf() = %q does not pass NewCpf: %v", cpf, err)
+				}
+				if tt.region != FiscalRegionUnknown && cpf.FiscalRegion() != tt.region {
+					t.Fatalf("GenerateCpf() = %q region %v, want %v", cpf, cpf.FiscalRegion(), tt.region)
+				}
+			}
+		})
+	}
+}
+
+// Test that generated CNPJs always pass NewCnpj and honour kind and branch options
+func TestGenerateCnpj(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           []GenerateOption
+		expectedKind   Kind
+		expectedBranch string
+	}{
+		{"Default numeric headquarters", nil, KindCNPJNumeric, "0001"},
+		{"Alphanumeric", []GenerateOption{WithAlphanumeric()}, KindCNPJAlphanumeric, "0001"},
+		{"Given branch", []GenerateOption{WithBranch(42)}, KindCNPJNumeric, "0042"},
+		{"Highest branch", []GenerateOption{WithBranch(9999)}, KindCNPJNumeric, "9999"},
+		{"Alphanumeric with branch", []GenerateOption{WithAlphanumeric(), WithBranch(7)}, KindCNPJAlphanumeric, "0007"},
+		{"Crypto source", []GenerateOption{WithCryptoRand(), WithAlphanumeric()}, KindCNPJAlphanumeric, "0001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 200; i++ {
+				cnpj, err := GenerateCnpj(tt.opts...)
+				if err != nil {
+					t.Fatalf("GenerateCnpj() unexpected error: %v", err)
+				}
+				if _, err := NewCnpj(cnpj.Raw()); err != nil {
+					t.Fatalf("GenerateCnpj() = %q does not pass NewCnpj: %v", cnpj, err)
+				}
+				if cnpj.Kind() != tt.expectedKind {
+					t.Fatalf("GenerateCnpj() = %q kind %v, want %v", cnpj, cnpj.Kind(), tt.expectedKind)
+				}
+				if branch := cnpj.Raw()[8:12]; branch != tt.expectedBranch {
+					t.Fatalf("GenerateCnpj() = %q branch %q, want %q", cnpj, branch, tt.expectedBranch)
+				}
+			}
+		})
+	}
+}
+
+// Test that a seeded source produces reproducible documents
+func TestGenerate_Deterministic(t *testing.T) {
+	generate := func() (CPF, CNPJ) {
+		r := rand.New(rand.NewPCG(42, 1024))
+		cpf, err := GenerateCpf(WithRand(r))
+		if err != nil {
+			t.Fatalf("GenerateCpf() unexpected error: %v", err)
+		}
+		cnpj, err := GenerateCnpj(WithRand(r), WithAlphanumeric())
+		if err != nil {
+			t.Fatalf("GenerateCnpj() unexpected error: %v", err)
+		}
+		return cpf, cnpj
+	}
+
+	cpf1, cnpj1 := generate()
+	cpf2, cnpj2 := generate()
+	if cpf1 != cpf2 || cnpj1 != cnpj2 {
+		t.Errorf("seeded generation not reproducible: (%q, %q) != (%q, %q)", cpf1, cnpj1, cpf2, cnpj2)
+	}
+}
+
+// Test that invalid options are rejected
+func TestGenerate_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		generate func() error
+	}{
+		{"Fiscal region too high", func() error { _, err := GenerateCpf(WithFiscalRegion(10)); return err }},
+		{"Fiscal region negative", func() error { _, err := GenerateCpf(WithFiscalRegion(-5)); return err }},
+		{"Branch zero", func() error { _, err := GenerateCnpj(WithBranch(0)); return err }},
+		{"Branch too high", func() error { _, err := GenerateCnpj(WithBranch(10000)); return err }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.generate(); !errors.Is(err, ErrInvalidOption) {
+				t.Errorf("expected %v, got %v", ErrInvalidOption, err)
+			}
+		})
+	}
+}
+
+// Benchmark document generation
+func BenchmarkGenerate(b *testing.B) {
+	r := rand.New(rand.NewPCG(1, 2))
+
+	b.Run("CPF", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = GenerateCpf(WithRand(r))
+		}
+	})
+
+	b.Run("CNPJ_Alphanumeric", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = GenerateCnpj(WithRand(r), WithAlphanumeric())
+		}
+	})
+}
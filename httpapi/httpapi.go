@@ -0,0 +1,221 @@
+// Package httpapi exposes CPF and CNPJ validation over HTTP with JSON responses, so services
+// written in other languages get exactly the semantics of cpfcnpj.NewCpf and cpfcnpj.NewCnpj.
+//
+// Routes:
+//
+//	GET  /v1/validate/{doc}    validate one document
+//	POST /v1/validate:batch    validate {"documents": [...]}
+//
+// Both accept an optional kind of "cpf", "cnpj" or "any" (the default), as the kind query
+// parameter or the kind field of the batch request.
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	cpfcnpj "github.com/n0vdd/cpf_cnpj"
+)
+
+// DefaultMaxBatchSize is the number of documents accepted per batch request when
+// Options.MaxBatchSize is not set.
+const DefaultMaxBatchSize = 1000
+
+// Room left in a batch body for the JSON syntax around each document (quotes, comma and
+// whitespace) and around the whole request
+const (
+	documentOverhead = 16
+	requestOverhead  = 1024
+)
+
+// Request error codes, reported in ErrorResponse.Code
+const (
+	CodeBadRequest      = "bad_request"
+	CodeUnknownKind     = "unknown_kind"
+	CodeRequestTooLarge = "request_too_large"
+	CodeBatchTooLarge   = "batch_too_large"
+)
+
+// Options configures the handler returned by NewHandler.
+type Options struct {
+	// MaxBatchSize is the maximum number of documents in a batch request.
+	// Zero or negative uses DefaultMaxBatchSize.
+	MaxBatchSize int
+	// Workers is the number of goroutines validating a batch. Zero or negative uses runtime.GOMAXPROCS(0).
+	Workers int
+}
+
+// Result is the validation outcome for one document.
+type Result struct {
+	Input     string            `json:"input"`
+	Valid     bool              `json:"valid"`
+	Kind      string            `json:"kind,omitempty"`
+	Raw       string            `json:"raw,omitempty"`
+	Formatted string            `json:"formatted,omitempty"`
+	Masked    string            `json:"masked,omitempty"`
+	Code      cpfcnpj.ErrorCode `json:"code,omitempty"`
+	Error     string            `json:"error,omitempty"`
+}
+
+// BatchRequest is the body of POST /v1/validate:batch.
+type BatchRequest struct {
+	Documents []string `json:"documents"`
+	Kind      string   `json:"kind,omitempty"`
+}
+
+// BatchResponse is the body returned by POST /v1/validate:batch, with one result per document in request order.
+type BatchResponse struct {
+	Results []Result `json:"results"`
+	Valid   int      `json:"valid"`
+	Invalid int      `json:"invalid"`
+}
+
+// ErrorResponse is returned when a request cannot be processed, as opposed to a document being invalid.
+type ErrorResponse struct {
+	Code  string `json:"code"`
+	Error string `json:"error"`
+}
+
+// handler serves the validation routes.
+type handler struct {
+	maxBatchSize int
+	maxBodySize  int64
+	workers      int
+}
+
+// NewHandler returns an http.Handler serving the validation routes.
+//
+// A single validation responds 200 when the document is valid and 422 when it is not, with a
+// Result body in both cases. A batch responds 200 with a BatchResponse, whatever its documents.
+// Batch bodies are limited to MaxBatchSize documents of cpfcnpj.MaxInputSize characters, plus
+// room for the JSON syntax; larger bodies get 413.
+func NewHandler(opts Options) http.Handler {
+	h := &handler{maxBatchSize: opts.MaxBatchSize, workers: opts.Workers}
+	if h.maxBatchSize <= 0 {
+		h.maxBatchSize = DefaultMaxBatchSize
+	}
+	h.maxBodySize = int64(h.maxBatchSize)*(cpfcnpj.MaxInputSize+documentOverhead) + requestOverhead
+
+	mux := http.NewServeMux()
+	// {doc...} also matches the slash in formatted CNPJs such as 22.796.729/0001-59
+	mux.HandleFunc("GET /v1/validate/{doc...}", h.validate)
+	mux.HandleFunc("POST /v1/validate:batch", h.validateBatch)
+	return mux
+}
+
+// validate serves GET /v1/validate/{doc}.
+func (h *handler) validate(w http.ResponseWriter, r *http.Request) {
+	kind, err := kindParam(r.URL.Query().Get("kind"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, CodeUnknownKind, err)
+		return
+	}
+
+	input := r.PathValue("doc")
+	doc, err := cpfcnpj.ParseKind(kind, input)
+	result := newResult(input, doc, err)
+
+	status := http.StatusOK
+	if !result.Valid {
+		status = http.StatusUnprocessableEntity
+	}
+	writeJSON(w, status, result)
+}
+
+// validateBatch serves POST /v1/validate:batch.
+func (h *handler) validateBatch(w http.ResponseWriter, r *http.Request) {
+	var req BatchRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.maxBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, CodeRequestTooLarge,
+				fmt.Errorf("request body exceeds %d bytes", tooLarge.Limit))
+			return
+		}
+		writeError(w, http.StatusBadRequest, CodeBadRequest, fmt.Errorf("invalid JSON body: %w", err))
+		return
+	}
+
+	if len(req.Documents) > h.maxBatchSize {
+		writeError(w, http.StatusRequestEntityTooLarge, CodeBatchTooLarge,
+			fmt.Errorf("batch has %d documents, maximum is %d", len(req.Documents), h.maxBatchSize))
+		return
+	}
+	kind, err := kindParam(req.Kind)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, CodeUnknownKind, err)
+		return
+	}
+
+	results := cpfcnpj.ValidateBatch(r.Context(), req.Documents, cpfcnpj.BatchOptions{Workers: h.workers, Kind: kind})
+	if err := r.Context().Err(); err != nil {
+		// The client is gone; there is nobody to respond to
+		return
+	}
+
+	resp := BatchResponse{Results: make([]Result, len(results))}
+	for i, res := range results {
+		resp.Results[i] = newResult(res.Input, res.Document, res.Err)
+		if resp.Results[i].Valid {
+			resp.Valid++
+		} else {
+			resp.Invalid++
+		}
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// kindParam converts a kind parameter to the cpfcnpj.Kind selecting the validator.
+// KindUnknown means auto-detection.
+func kindParam(kind string) (cpfcnpj.Kind, error) {
+	switch kind {
+	case "", "any":
+		return cpfcnpj.KindUnknown, nil
+	case "cpf":
+		return cpfcnpj.KindCPF, nil
+	case "cnpj":
+		return cpfcnpj.KindCNPJNumeric, nil
+	default:
+		return cpfcnpj.KindUnknown, fmt.Errorf("unknown kind %q, want cpf, cnpj or any", kind)
+	}
+}
+
+// newResult describes the outcome of validating input.
+func newResult(input string, doc cpfcnpj.Document, err error) Result {
+	r := Result{Input: input}
+	if err != nil {
+		r.Code = cpfcnpj.CodeOf(err)
+		r.Error = err.Error()
+
+		var verr *cpfcnpj.ValidationError
+		if errors.As(err, &verr) {
+			r.Kind = verr.Kind.String()
+		}
+		return r
+	}
+
+	r.Valid = true
+	r.Kind = doc.Kind().String()
+	r.Raw = doc.Raw()
+	r.Formatted = doc.String()
+	r.Masked = doc.Masked()
+	return r
+}
+
+// writeError writes an ErrorResponse.
+func writeError(w http.ResponseWriter, status int, code string, err error) {
+	writeJSON(w, status, ErrorResponse{Code: code, Error: err.Error()})
+}
+
+// writeJSON writes v as the JSON response body.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	// The status line is already sent, so an encoding error can only be a broken connection
+	_ = json.NewEncoder(w).Encode(v)
+}
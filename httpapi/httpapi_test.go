@@ -0,0 +1,239 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	cpfcnpj "github.com/n0vdd/cpf_cnpj"
+)
+
+// Test single document validation over GET
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expected       Result
+	}{
+		{
+			name:           "Valid CPF",
+			path:           "/v1/validate/716.566.867-59",
+			expectedStatus: http.StatusOK,
+			expected: Result{Input: "716.566.867-59", Valid: true, Kind: "cpf", Raw: "71656686759",
+				Formatted: "716.566.867-59", Masked: "***.566.867-**"},
+		},
+		{
+			name:           "Formatted CNPJ with a slash",
+			path:           "/v1/validate/22.796.729/0001-59",
+			expectedStatus: http.StatusOK,
+			expected: Result{Input: "22.796.729/0001-59", Valid: true, Kind: "cnpj_numeric",
+				Raw: "22796729000159", Formatted: "22.796.729/0001-59", Masked: "22.796.729/****-**"},
+		},
+		{
+			name:           "Escaped slash",
+			path:           "/v1/validate/12.ABC.345%2F01DE-35",
+			expectedStatus: http.StatusOK,
+			expected: Result{Input: "12.ABC.345/01DE-35", Valid: true, Kind: "cnpj_alphanumeric",
+				Raw: "12ABC34501DE35", Formatted: "12.ABC.345/01DE-35", Masked: "12.ABC.345/****-**"},
+		},
+		{
+			name:           "Invalid checksum",
+			path:           "/v1/validate/71656686758",
+			expectedStatus: http.StatusUnprocessableEntity,
+			expected: Result{Input: "71656686758", Kind: "cpf", Code: cpfcnpj.CodeInvalidChecksum,
+				Error: "CPF check digits are invalid: CPF checksum validation failed"},
+		},
+		{
+			name:           "Kind parameter",
+			path:           "/v1/validate/71656686759?kind=cnpj",
+			expectedStatus: http.StatusUnprocessableEntity,
+			expected: Result{Input: "71656686759", Kind: "cnpj_numeric", Code: cpfcnpj.CodeInvalidLength,
+				Error: "CNPJ must have exactly 14 characters, got 11: CNPJ must have exactly 14 characters"},
+		},
+		{
+			name:           "Oversized input",
+			path:           "/v1/validate/" + strings.Repeat("1", cpfcnpj.MaxInputSize+1),
+			expectedStatus: http.StatusUnprocessableEntity,
+			expected: Result{Input: strings.Repeat("1", cpfcnpj.MaxInputSize+1), Kind: "unknown",
+				Code: cpfcnpj.CodeInputTooLarge, Error: "document input has 1001 characters: " +
+					"input string too large: maximum 1000 characters allowed"},
+		},
+	}
+
+	handler := NewHandler(Options{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("status = %d, want %d (body: %s)", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json; charset=utf-8" {
+				t.Errorf("Content-Type = %q", ct)
+			}
+
+			var got Result
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("invalid JSON %q: %v", rec.Body.String(), err)
+			}
+			if got != tt.expected {
+				t.Errorf("result = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// Test batch validation results, counts and order
+func TestValidateBatch(t *testing.T) {
+	body := `{"documents": ["716.566.867-59", "12ABC34501DE35", "716.566.867-58", "123"]}`
+
+	rec := httptest.NewRecorder()
+	NewHandler(Options{Workers: 2}).ServeHTTP(rec,
+		httptest.NewRequest(http.MethodPost, "/v1/validate:batch", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (body: %s)", rec.Code, http.StatusOK, rec.Body.String())
+	}
+
+	var resp BatchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON %q: %v", rec.Body.String(), err)
+	}
+	if resp.Valid != 2 || resp.Invalid != 2 || len(resp.Results) != 4 {
+		t.Fatalf("response = %+v, want 2 valid and 2 invalid results", resp)
+	}
+
+	expected := []struct {
+		input string
+		kind  string
+		code  cpfcnpj.ErrorCode
+	}{
+		{"716.566.867-59", "cpf", ""},
+		{"12ABC34501DE35", "cnpj_alphanumeric", ""},
+		{"716.566.867-58", "cpf", cpfcnpj.CodeInvalidChecksum},
+		{"123", "unknown", cpfcnpj.CodeInvalidLength},
+	}
+	for i, want := range expected {
+		got := resp.Results[i]
+		if got.Input != want.input || got.Kind != want.kind || got.Code != want.code || got.Valid != (want.code == "") {
+			t.Errorf("results[%d] = %+v, want input %q, kind %q, code %q", i, got, want.input, want.kind, want.code)
+		}
+	}
+}
+
+// Test the request errors of both routes
+func TestHandler_RequestErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{
+			name:           "Unknown kind parameter",
+			method:         http.MethodGet,
+			path:           "/v1/validate/71656686759?kind=rg",
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeUnknownKind,
+		},
+		{
+			name:           "Unknown batch kind",
+			method:         http.MethodPost,
+			path:           "/v1/validate:batch",
+			body:           `{"documents": ["71656686759"], "kind": "rg"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeUnknownKind,
+		},
+		{
+			name:           "Malformed JSON",
+			method:         http.MethodPost,
+			path:           "/v1/validate:batch",
+			body:           `{"documents": [`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeBadRequest,
+		},
+		{
+			name:           "Unknown field",
+			method:         http.MethodPost,
+			path:           "/v1/validate:batch",
+			body:           `{"docs": ["71656686759"]}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeBadRequest,
+		},
+		{
+			name:           "Too many documents",
+			method:         http.MethodPost,
+			path:           "/v1/validate:batch",
+			body:           `{"documents": ["1", "2", "3"]}`,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedCode:   CodeBatchTooLarge,
+		},
+		{
+			name:           "Body too large",
+			method:         http.MethodPost,
+			path:           "/v1/validate:batch",
+			body:           `{"documents": ["` + strings.Repeat("1", 2*(cpfcnpj.MaxInputSize+documentOverhead)+requestOverhead) + `"]}`,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedCode:   CodeRequestTooLarge,
+		},
+	}
+
+	handler := NewHandler(Options{MaxBatchSize: 2})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("status = %d, want %d (body: %s)", rec.Code, tt.expectedStatus, rec.Body.String())
+			}
+			var got ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("invalid JSON %q: %v", rec.Body.String(), err)
+			}
+			if got.Code != tt.expectedCode || got.Error == "" {
+				t.Errorf("error response = %+v, want code %q", got, tt.expectedCode)
+			}
+		})
+	}
+}
+
+// Test that routes only answer their own method
+func TestHandler_MethodNotAllowed(t *testing.T) {
+	handler := NewHandler(Options{})
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/v1/validate/71656686759", nil),
+		httptest.NewRequest(http.MethodGet, "/v1/validate:batch", nil),
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("%s %s status = %d, want %d", req.Method, req.URL.Path, rec.Code, http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// Test the handler through a real HTTP server
+func TestHandler_Server(t *testing.T) {
+	server := httptest.NewServer(NewHandler(Options{}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/v1/validate/22.796.729/0001-59")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var got Result
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusOK || !got.Valid || got.Raw != "22796729000159" {
+		t.Errorf("status %d, result %+v", resp.StatusCode, got)
+	}
+}
@@ -0,0 +1,182 @@
+package cpfcnpj
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Compile-time interface checks
+var (
+	_ json.Marshaler   = CPF("")
+	_ json.Unmarshaler = (*CPF)(nil)
+	_ json.Marshaler   = CNPJ("")
+	_ json.Unmarshaler = (*CNPJ)(nil)
+	_ json.Marshaler   = FormattedCPF("")
+	_ json.Unmarshaler = (*FormattedCPF)(nil)
+	_ json.Marshaler   = FormattedCNPJ("")
+	_ json.Unmarshaler = (*FormattedCNPJ)(nil)
+	_ json.Marshaler   = TaxID("")
+	_ json.Unmarshaler = (*TaxID)(nil)
+)
+
+// FormattedCPF is a CPF that marshals in its formatted form ("716.566.867-59").
+// Use it for struct fields that must be rendered formatted; unmarshaling validates
+// exactly like CPF. Convert with FormattedCPF(cpf) and CPF(formatted).
+type FormattedCPF CPF
+
+// FormattedCNPJ is a CNPJ that marshals in its formatted form ("22.796.729/0001-59").
+// Use it for struct fields that must be rendered formatted; unmarshaling validates
+// exactly like CNPJ. Convert with FormattedCNPJ(cnpj) and CNPJ(formatted).
+type FormattedCNPJ CNPJ
+
+// MarshalText implements encoding.TextMarshaler, returning the raw digits.
+func (c CPF) MarshalText() ([]byte, error) {
+	return []byte(c.Raw()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The input is validated with NewCpf,
+// so formatted and clean values are accepted. Empty input produces the zero value.
+func (c *CPF) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*c = ""
+		return nil
+	}
+
+	cpf, err := NewCpf(string(text))
+	if err != nil {
+		return err
+	}
+	*c = cpf
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the raw digits as a JSON string.
+func (c CPF) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Raw())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON string is validated with NewCpf;
+// null and "" produce the zero value.
+func (c *CPF) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONString(data, "CPF", c.UnmarshalText)
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the raw characters.
+func (c CNPJ) MarshalText() ([]byte, error) {
+	return []byte(c.Raw()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The input is validated with NewCnpj,
+// so formatted, clean and lowercase values are accepted. Empty input produces the zero value.
+func (c *CNPJ) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*c = ""
+		return nil
+	}
+
+	cnpj, err := NewCnpj(string(text))
+	if err != nil {
+		return err
+	}
+	*c = cnpj
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the raw characters as a JSON string.
+func (c CNPJ) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.Raw())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON string is validated with NewCnpj;
+// null and "" produce the zero value.
+func (c *CNPJ) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONString(data, "CNPJ", c.UnmarshalText)
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the raw characters.
+func (t TaxID) MarshalText() ([]byte, error) {
+	return []byte(t.Raw()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The input is validated with Parse,
+// which detects whether it is a CPF or a CNPJ. Empty input produces the zero value.
+func (t *TaxID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*t = ""
+		return nil
+	}
+
+	id, err := NewTaxID(string(text))
+	if err != nil {
+		return err
+	}
+	*t = id
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the raw characters as a JSON string.
+func (t TaxID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Raw())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON string is validated with Parse;
+// null and "" produce the zero value.
+func (t *TaxID) UnmarshalJSON(data []byte) error {
+	return unmarshalJSONString(data, "TaxID", t.UnmarshalText)
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the formatted CPF.
+// The zero value marshals to empty text.
+func (f FormattedCPF) MarshalText() ([]byte, error) {
+	return []byte(CPF(f).String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler with the same validation as CPF.
+func (f *FormattedCPF) UnmarshalText(text []byte) error {
+	return (*CPF)(f).UnmarshalText(text)
+}
+
+// MarshalJSON implements json.Marshaler, encoding the formatted CPF as a JSON string.
+func (f FormattedCPF) MarshalJSON() ([]byte, error) {
+	return json.Marshal(CPF(f).String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler with the same validation as CPF.
+func (f *FormattedCPF) UnmarshalJSON(data []byte) error {
+	return (*CPF)(f).UnmarshalJSON(data)
+}
+
+// MarshalText implements encoding.TextMarshaler, returning the formatted CNPJ.
+// The zero value marshals to empty text.
+func (f FormattedCNPJ) MarshalText() ([]byte, error) {
+	return []byte(CNPJ(f).String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler with the same validation as CNPJ.
+func (f *FormattedCNPJ) UnmarshalText(text []byte) error {
+	return (*CNPJ)(f).UnmarshalText(text)
+}
+
+// MarshalJSON implements json.Marshaler, encoding the formatted CNPJ as a JSON string.
+func (f FormattedCNPJ) MarshalJSON() ([]byte, error) {
+	return json.Marshal(CNPJ(f).String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler with the same validation as CNPJ.
+func (f *FormattedCNPJ) UnmarshalJSON(data []byte) error {
+	return (*CNPJ)(f).UnmarshalJSON(data)
+}
+
+// unmarshalJSONString decodes a JSON string and hands it to unmarshalText.
+// JSON null is treated like an empty string.
+func unmarshalJSONString(data []byte, name string, unmarshalText func([]byte) error) error {
+	if string(data) == "null" {
+		return unmarshalText(nil)
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("%s must be a JSON string: %w", name, err)
+	}
+	return unmarshalText([]byte(s))
+}
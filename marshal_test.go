@@ -0,0 +1,239 @@
+package cpfcnpj
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// Test DTO using document types as fields
+type marshalTestDTO struct {
+	Person      CPF           `json:"person"`
+	Company     CNPJ          `json:"company"`
+	Display     FormattedCPF  `json:"display,omitempty"`
+	DisplayCNPJ FormattedCNPJ `json:"display_cnpj,omitempty"`
+}
+
+// Test JSON unmarshaling with validation
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		expectedPerson  CPF
+		expectedCompany CNPJ
+		expectedErr     error
+	}{
+		{
+			name:            "Clean values",
+			input:           `{"person":"71656686759","company":"22796729000159"}`,
+			expectedPerson:  "71656686759",
+			expectedCompany: "22796729000159",
+		},
+		{
+			name:            "Formatted values",
+			input:           `{"person":"716.566.867-59","company":"12.abc.345/01de-35"}`,
+			expectedPerson:  "71656686759",
+			expectedCompany: "12ABC34501DE35",
+		},
+		{
+			name:  "Empty strings map to zero values",
+			input: `{"person":"","company":""}`,
+		},
+		{
+			name:  "Null maps to zero values",
+			input: `{"person":null,"company":null}`,
+		},
+		{
+			name:  "Missing fields keep zero values",
+			input: `{}`,
+		},
+		{
+			name:        "Invalid CPF checksum",
+			input:       `{"person":"716.566.867-58"}`,
+			expectedErr: ErrCPFInvalidChecksum,
+		},
+		{
+			name:        "Invalid CNPJ length",
+			input:       `{"company":"123"}`,
+			expectedErr: ErrCNPJInvalidLength,
+		},
+		{
+			name:        "Formatted type validates too",
+			input:       `{"display":"111.111.111-11"}`,
+			expectedErr: ErrAllSameDigits,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dto marshalTestDTO
+			err := json.Unmarshal([]byte(tt.input), &dto)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("json.Unmarshal(%s) error = %v, want %v", tt.input, err, tt.expectedErr)
+			}
+			if dto.Person != tt.expectedPerson {
+				t.Errorf("Person = %q, want %q", dto.Person, tt.expectedPerson)
+			}
+			if dto.Company != tt.expectedCompany {
+				t.Errorf("Company = %q, want %q", dto.Company, tt.expectedCompany)
+			}
+		})
+	}
+}
+
+// Test that non-string JSON values are rejected
+func TestUnmarshalJSON_NonString(t *testing.T) {
+	inputs := []string{`{"person":71656686759}`, `{"company":true}`, `{"person":["71656686759"]}`}
+
+	for _, input := range inputs {
+		var dto marshalTestDTO
+		if err := json.Unmarshal([]byte(input), &dto); err == nil {
+			t.Errorf("json.Unmarshal(%s) expected error, got nil", input)
+		}
+	}
+}
+
+// Test JSON marshaling in raw and formatted forms
+func TestMarshalJSON(t *testing.T) {
+	dto := marshalTestDTO{
+		Person:      "71656686759",
+		Company:     "12ABC34501DE35",
+		Display:     FormattedCPF("71656686759"),
+		DisplayCNPJ: FormattedCNPJ("22796729000159"),
+	}
+
+	data, err := json.Marshal(dto)
+	if err != nil {
+		t.Fatalf("json.Marshal unexpected error: %v", err)
+	}
+
+	expected := `{"person":"71656686759","company":"12ABC34501DE35",` +
+		`"display":"716.566.867-59","display_cnpj":"22.796.729/0001-59"}`
+	if string(data) != expected {
+		t.Errorf("json.Marshal = %s, want %s", data, expected)
+	}
+
+	// Round trip through the validating unmarshalers
+	var decoded marshalTestDTO
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal round trip unexpected error: %v", err)
+	}
+	if decoded != dto {
+		t.Errorf("round trip = %+v, want %+v", decoded, dto)
+	}
+}
+
+// Test text marshaling used by encoding packages and map keys
+func TestTextMarshaling(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{ MarshalText() ([]byte, error) }
+		expected string
+	}{
+		{"CPF raw", CPF("71656686759"), "71656686759"},
+		{"CNPJ raw", CNPJ("12ABC34501DE35"), "12ABC34501DE35"},
+		{"FormattedCPF", FormattedCPF("71656686759"), "716.566.867-59"},
+		{"FormattedCNPJ", FormattedCNPJ("12ABC34501DE35"), "12.ABC.345/01DE-35"},
+		{"Zero CPF", CPF(""), ""},
+		{"Zero FormattedCNPJ", FormattedCNPJ(""), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.value.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText unexpected error: %v", err)
+			}
+			if string(got) != tt.expected {
+				t.Errorf("MarshalText() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	// Documents work as map keys
+	m := map[CPF]int{"71656686759": 1}
+	data, err := json.Marshal(m)
+	if err != nil || string(data) != `{"71656686759":1}` {
+		t.Errorf("json.Marshal(map) = %s, %v", data, err)
+	}
+
+	var decoded map[CNPJ]int
+	if err := json.Unmarshal([]byte(`{"22.796.729/0001-59":1}`), &decoded); err != nil {
+		t.Fatalf("json.Unmarshal(map) unexpected error: %v", err)
+	}
+	if decoded["22796729000159"] != 1 {
+		t.Errorf("json.Unmarshal(map) = %v, want key 22796729000159", decoded)
+	}
+}
+
+// Test that TaxID detects the document kind on decode and round trips
+func TestTaxIDJSON(t *testing.T) {
+	type payment struct {
+		Payee TaxID `json:"payee"`
+	}
+
+	tests := []struct {
+		name         string
+		input        string
+		expected     TaxID
+		expectedKind Kind
+		expectedErr  error
+	}{
+		{"CPF", `{"payee":"716.566.867-59"}`, "71656686759", KindCPF, nil},
+		{"Numeric CNPJ", `{"payee":"22.796.729/0001-59"}`, "22796729000159", KindCNPJNumeric, nil},
+		{"Alphanumeric CNPJ", `{"payee":"12abc34501de35"}`, "12ABC34501DE35", KindCNPJAlphanumeric, nil},
+		{"Null", `{"payee":null}`, "", KindUnknown, nil},
+		{"Empty", `{"payee":""}`, "", KindUnknown, nil},
+		{"Invalid CPF", `{"payee":"716.566.867-58"}`, "", KindUnknown, ErrCPFInvalidChecksum},
+		{"Unknown length", `{"payee":"123"}`, "", KindUnknown, ErrUnknownDocument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p payment
+			err := json.Unmarshal([]byte(tt.input), &p)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("json.Unmarshal(%s) error = %v, want %v", tt.input, err, tt.expectedErr)
+			}
+			if p.Payee != tt.expected || p.Payee.Kind() != tt.expectedKind {
+				t.Errorf("Payee = %q (%v), want %q (%v)", p.Payee, p.Payee.Kind(), tt.expected, tt.expectedKind)
+			}
+			if err != nil {
+				return
+			}
+
+			data, err := json.Marshal(p)
+			if err != nil {
+				t.Fatalf("json.Marshal unexpected error: %v", err)
+			}
+			var decoded payment
+			if err := json.Unmarshal(data, &decoded); err != nil || decoded != p {
+				t.Errorf("round trip = %+v, %v, want %+v", decoded, err, p)
+			}
+		})
+	}
+
+	// TaxIDs work as map keys
+	var byPayee map[TaxID]int
+	if err := json.Unmarshal([]byte(`{"716.566.867-59":1,"22.796.729/0001-59":2}`), &byPayee); err != nil {
+		t.Fatalf("json.Unmarshal(map) unexpected error: %v", err)
+	}
+	if byPayee["71656686759"] != 1 || byPayee["22796729000159"] != 2 {
+		t.Errorf("json.Unmarshal(map) = %v", byPayee)
+	}
+}
+
+// Test that UnmarshalText returns the package validation errors
+func TestUnmarshalText_Errors(t *testing.T) {
+	var cpf CPF
+	err := cpf.UnmarshalText([]byte("123"))
+	if CodeOf(err) != CodeInvalidLength {
+		t.Errorf("CPF.UnmarshalText(\"123\") code = %q, want %q", CodeOf(err), CodeInvalidLength)
+	}
+
+	var cnpj FormattedCNPJ
+	err = cnpj.UnmarshalText([]byte("12ABC34501DE99"))
+	if !errors.Is(err, ErrCNPJInvalidChecksum) {
+		t.Errorf("FormattedCNPJ.UnmarshalText error = %v, want %v", err, ErrCNPJInvalidChecksum)
+	}
+}
@@ -0,0 +1,119 @@
+package cpfcnpj
+
+import "strings"
+
+// maskStyleKind enumerates the masking strategies supported by MaskStyle.
+type maskStyleKind int
+
+const (
+	maskGovBR maskStyleKind = iota
+	maskLastVisible
+	maskRootVisible
+)
+
+// MaskStyle selects which characters of a document stay visible when masking for
+// display under LGPD. Hidden characters are replaced by '*' and formatting is kept.
+type MaskStyle struct {
+	kind    maskStyleKind
+	visible int
+}
+
+// Predefined mask styles
+var (
+	// MaskGovBR follows the gov.br convention of hiding the first group and the check digits:
+	// "***.566.867-**" for CPF and "**.796.729/0001-**" for CNPJ.
+	MaskGovBR = MaskStyle{kind: maskGovBR}
+
+	// MaskRootVisible keeps only the CNPJ root visible: "22.796.729/****-**".
+	// CPFs have no root and are masked with MaskGovBR instead.
+	MaskRootVisible = MaskStyle{kind: maskRootVisible}
+)
+
+// MaskLastVisible keeps only the last n characters visible, e.g. "***.***.**7-59" for n = 3.
+// Values of n below zero hide everything; values above the document length show everything.
+func MaskLastVisible(n int) MaskStyle {
+	return MaskStyle{kind: maskLastVisible, visible: n}
+}
+
+// isVisible reports whether the character at position i of a raw document of the given kind stays visible.
+func (s MaskStyle) isVisible(kind Kind, i int) bool {
+	length := CPFLength
+	if kind.IsCNPJ() {
+		length = CNPJLength
+	}
+
+	switch s.kind {
+	case maskLastVisible:
+		return i >= length-s.visible
+	case maskRootVisible:
+		if kind.IsCNPJ() {
+			return i < CNPJRootLength
+		}
+	case maskGovBR:
+	}
+
+	// gov.br: hide the first group and the two check digits
+	if kind.IsCNPJ() {
+		return i >= 2 && i < CNPJBaseLength
+	}
+	return i >= 3 && i < CPFBaseLength
+}
+
+// pattern merges the style into a formatting pattern, turning hidden 'X' positions into '*'
+// so the result can be rendered with formatDocument.
+func (s MaskStyle) pattern(kind Kind, format string) string {
+	var result strings.Builder
+	result.Grow(len(format))
+
+	pos := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != 'X' {
+			result.WriteByte(format[i])
+			continue
+		}
+		if s.isVisible(kind, pos) {
+			result.WriteByte('X')
+		} else {
+			result.WriteByte('*')
+		}
+		pos++
+	}
+
+	return result.String()
+}
+
+// matchesMasked compares raw against a masked value read back by a person.
+// Formatting characters in masked are ignored, letters are compared case-insensitively
+// and '*' matches any character. At least one character must be visible.
+func matchesMasked(raw, masked string) bool {
+	if len(raw) == 0 || len(masked) > MaxInputSize {
+		return false
+	}
+
+	pos := 0
+	visible := 0
+	for i := 0; i < len(masked); i++ {
+		ch := masked[i]
+		switch {
+		case ch == '*':
+		case ch >= '0' && ch <= '9', ch >= 'A' && ch <= 'Z':
+		case ch >= 'a' && ch <= 'z':
+			ch -= 'a' - 'A'
+		default:
+			continue // formatting character
+		}
+
+		if pos >= len(raw) {
+			return false
+		}
+		if ch != '*' {
+			if ch != raw[pos] {
+				return false
+			}
+			visible++
+		}
+		pos++
+	}
+
+	return pos == len(raw) && visible > 0
+}
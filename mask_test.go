@@ -0,0 +1,100 @@
+package cpfcnpj
+
+import (
+	"strings"
+	"testing"
+)
+
+// Test masking styles for CPF and CNPJ
+func TestMaskedWith(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      Document
+		style    MaskStyle
+		expected string
+	}{
+		// CPF
+		{"CPF gov.br", CPF("71656686759"), MaskGovBR, "***.566.867-**"},
+		{"CPF last 2 visible", CPF("71656686759"), MaskLastVisible(2), "***.***.***-59"},
+		{"CPF last 4 visible", CPF("71656686759"), MaskLastVisible(4), "***.***.*67-59"},
+		{"CPF nothing visible", CPF("71656686759"), MaskLastVisible(0), "***.***.***-**"},
+		{"CPF negative visible", CPF("71656686759"), MaskLastVisible(-3), "***.***.***-**"},
+		{"CPF everything visible", CPF("71656686759"), MaskLastVisible(20), "716.566.867-59"},
+		{"CPF root visible falls back to gov.br", CPF("71656686759"), MaskRootVisible, "***.566.867-**"},
+
+		// CNPJ
+		{"CNPJ gov.br", CNPJ("22796729000159"), MaskGovBR, "**.796.729/0001-**"},
+		{"CNPJ root visible", CNPJ("22796729000159"), MaskRootVisible, "22.796.729/****-**"},
+		{"CNPJ last 6 visible", CNPJ("22796729000159"), MaskLastVisible(6), "**.***.***/0001-59"},
+		{"Alphanumeric CNPJ gov.br", CNPJ("12ABC34501DE35"), MaskGovBR, "**.ABC.345/01DE-**"},
+		{"Alphanumeric CNPJ root visible", CNPJ("12ABC34501DE35"), MaskRootVisible, "12.ABC.345/****-**"},
+
+		// Malformed values are never exposed
+		{"Wrong length CPF", CPF("1234"), MaskLastVisible(20), "****"},
+		{"Wrong length CNPJ", CNPJ("1234"), MaskRootVisible, "****"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.doc.MaskedWith(tt.style); got != tt.expected {
+				t.Errorf("MaskedWith() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+// Test matching masked values read back by a customer
+func TestMatchesMasked(t *testing.T) {
+	cpf := CPF("71656686759")
+	cnpj := CNPJ("12ABC34501DE35")
+
+	tests := []struct {
+		name     string
+		doc      Document
+		masked   string
+		expected bool
+	}{
+		// CPF
+		{"CPF gov.br mask", cpf, "***.566.867-**", true},
+		{"CPF unformatted mask", cpf, "***566867**", true},
+		{"CPF last digits", cpf, "***.***.***-59", true},
+		{"CPF full value", cpf, "716.566.867-59", true},
+		{"CPF wrong visible digit", cpf, "***.566.868-**", false},
+		{"CPF too short", cpf, "***.566.867-*", false},
+		{"CPF too long", cpf, "***.566.867-***", false},
+		{"CPF fully hidden", cpf, "***.***.***-**", false},
+		{"CPF empty", cpf, "", false},
+		{"Zero value CPF", CPF(""), "***.566.867-**", false},
+
+		// CNPJ
+		{"CNPJ root mask", cnpj, "12.ABC.345/****-**", true},
+		{"CNPJ lowercase read back", cnpj, "12.abc.345/****-**", true},
+		{"CNPJ gov.br mask", cnpj, "**.ABC.345/01DE-**", true},
+		{"CNPJ wrong letter", cnpj, "12.ABD.345/****-**", false},
+		{"CNPJ mask of CPF length", cnpj, "***.566.867-**", false},
+		{"Oversized input", cnpj, strings.Repeat("*", MaxInputSize+1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.doc.MatchesMasked(tt.masked); got != tt.expected {
+				t.Errorf("MatchesMasked(%q) = %v, want %v", tt.masked, got, tt.expected)
+			}
+		})
+	}
+}
+
+// Test that every style's output matches the document it came from
+func TestMaskedWith_RoundTrip(t *testing.T) {
+	styles := []MaskStyle{MaskGovBR, MaskRootVisible, MaskLastVisible(1), MaskLastVisible(5)}
+	docs := []Document{CPF("71656686759"), CNPJ("22796729000159"), CNPJ("12ABC34501DE35")}
+
+	for _, doc := range docs {
+		for _, style := range styles {
+			masked := doc.MaskedWith(style)
+			if !doc.MatchesMasked(masked) {
+				t.Errorf("%s.MatchesMasked(%q) = false for its own mask", doc.Raw(), masked)
+			}
+		}
+	}
+}
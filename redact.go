@@ -0,0 +1,165 @@
+package cpfcnpj
+
+import (
+	"io"
+	"strings"
+	"sync"
+)
+
+// RedactOptions configures Redact and RedactingWriter.
+type RedactOptions struct {
+	// Style is the mask applied to valid documents. The zero value is MaskGovBR.
+	Style MaskStyle
+
+	// Replace, when set, returns the replacement for a valid document instead of masking it
+	// with Style, e.g. to substitute a token that can be joined on later.
+	Replace func(Document) string
+}
+
+// replacement returns the redacted text for a valid match. Masked documents keep the
+// shape of the input: formatted matches stay formatted and unformatted ones stay unformatted.
+func (o RedactOptions) replacement(m Match) string {
+	doc := m.Document()
+	if o.Replace != nil {
+		return o.Replace(doc)
+	}
+
+	masked := doc.MaskedWith(o.Style)
+	if len(m.Text) == len(m.Raw) {
+		return stripFormatting(masked)
+	}
+	return masked
+}
+
+// Redact returns text with every valid CPF and CNPJ found by Extract replaced according to opts.
+// Matches with invalid check digits are left untouched.
+func Redact(text string, opts RedactOptions) string {
+	var result strings.Builder
+	result.Grow(len(text))
+
+	last := 0
+	scanDocuments(text, "", func(m Match) bool {
+		if m.Valid {
+			result.WriteString(text[last:m.Start])
+			result.WriteString(opts.replacement(m))
+			last = m.End
+		}
+		return true
+	})
+	result.WriteString(text[last:])
+
+	return result.String()
+}
+
+// heldBackLength is how many trailing bytes a RedactingWriter keeps until more input arrives:
+// the longest document plus the two bytes after it that decide whether it continues a run.
+const heldBackLength = maxMatchLength + 2
+
+// RedactingWriter is an io.Writer that redacts valid CPFs and CNPJs before passing data on.
+//
+// Documents split across Write calls are still recognised: up to heldBackLength bytes are held
+// back until the next Write, Flush or Close decides whether they start a document.
+// A RedactingWriter is safe for concurrent use.
+type RedactingWriter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	opts    RedactOptions
+	pending []byte
+	prev    string // last two bytes written to w, for the left boundary check
+	err     error
+}
+
+// NewRedactingWriter returns a RedactingWriter that writes redacted data to w.
+// Call Flush or Close when done to write the bytes still held back.
+func NewRedactingWriter(w io.Writer, opts RedactOptions) *RedactingWriter {
+	return &RedactingWriter{w: w, opts: opts}
+}
+
+// Write redacts p and writes everything that can no longer be part of a document to the underlying writer.
+func (rw *RedactingWriter) Write(p []byte) (int, error) {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	if rw.err != nil {
+		return 0, rw.err
+	}
+
+	rw.pending = append(rw.pending, p...)
+	if err := rw.process(false); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush redacts and writes all held-back bytes. A document split across a Flush is not recognised,
+// so flush only at natural boundaries such as the end of a log line.
+func (rw *RedactingWriter) Flush() error {
+	rw.mu.Lock()
+	defer rw.mu.Unlock()
+
+	if rw.err != nil {
+		return rw.err
+	}
+	return rw.process(true)
+}
+
+// Close flushes the RedactingWriter. It does not close the underlying writer.
+func (rw *RedactingWriter) Close() error {
+	return rw.Flush()
+}
+
+// process redacts the pending bytes and writes the part that is settled. When final is false,
+// the last heldBackLength bytes are kept unless a match starting before them covers them.
+func (rw *RedactingWriter) process(final bool) error {
+	text := string(rw.pending)
+
+	cut := len(text)
+	if !final {
+		cut -= heldBackLength
+	}
+
+	var out strings.Builder
+	last := 0
+	scanDocuments(text, rw.prev, func(m Match) bool {
+		if m.Start >= cut {
+			return false
+		}
+		out.WriteString(text[last:m.Start])
+		if m.Valid {
+			out.WriteString(rw.opts.replacement(m))
+		} else {
+			out.WriteString(m.Text)
+		}
+		last = m.End
+		return true
+	})
+
+	settled := max(cut, last)
+	if settled <= 0 {
+		return nil
+	}
+	out.WriteString(text[last:settled])
+
+	tail := rw.prev + text[max(0, settled-2):settled]
+	rw.prev = tail[max(0, len(tail)-2):]
+	rw.pending = append(rw.pending[:0], rw.pending[settled:]...)
+
+	if _, err := io.WriteString(rw.w, out.String()); err != nil {
+		rw.err = err
+		return err
+	}
+	return nil
+}
+
+// stripFormatting removes formatting characters from a masked document, keeping '*' placeholders.
+func stripFormatting(masked string) string {
+	var result strings.Builder
+	result.Grow(len(masked))
+
+	for i := 0; i < len(masked); i++ {
+		if ch := masked[i]; ch == '*' || isAlphanumericByte(ch) {
+			result.WriteByte(ch)
+		}
+	}
+	return result.String()
+}
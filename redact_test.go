@@ -0,0 +1,172 @@
+package cpfcnpj
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// Test one-shot redaction of free text
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		opts     RedactOptions
+		expected string
+	}{
+		{
+			name:     "Formatted CPF with default style",
+			text:     "cliente 716.566.867-59 ok",
+			expected: "cliente ***.566.867-** ok",
+		},
+		{
+			name:     "Unformatted documents keep their shape",
+			text:     "cpf=71656686759 cnpj=22796729000159",
+			expected: "cpf=***566867** cnpj=**7967290001**",
+		},
+		{
+			name:     "Alphanumeric CNPJ with root visible",
+			text:     "empresa 12.ABC.345/01DE-35 e 12ABC34501DE35",
+			opts:     RedactOptions{Style: MaskRootVisible},
+			expected: "empresa 12.ABC.345/****-** e 12ABC345******",
+		},
+		{
+			name:     "Lowercase alphanumeric CNPJ",
+			text:     "empresa 12.abc.345/01de-35 e 12abc34501de35",
+			expected: "empresa **.ABC.345/01DE-** e **ABC34501DE**",
+		},
+		{
+			name:     "Last visible style",
+			text:     "716.566.867-59",
+			opts:     RedactOptions{Style: MaskLastVisible(2)},
+			expected: "***.***.***-59",
+		},
+		{
+			name:     "Replace hook",
+			text:     "a 716.566.867-59 b 22796729000159",
+			opts:     RedactOptions{Replace: func(d Document) string { return "<" + d.Kind().String() + ">" }},
+			expected: "a <cpf> b <cnpj_numeric>",
+		},
+		{
+			name:     "Invalid documents are untouched",
+			text:     "716.566.867-58 22796729000158",
+			expected: "716.566.867-58 22796729000158",
+		},
+		{
+			name:     "Longer digit runs are untouched",
+			text:     "chave 35190722796729000159550010000001231000001234",
+			expected: "chave 35190722796729000159550010000001231000001234",
+		},
+		{
+			name:     "No documents",
+			text:     "nothing to see here",
+			expected: "nothing to see here",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Redact(tt.text, tt.opts); got != tt.expected {
+				t.Errorf("Redact(%q) = %q, want %q", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+// Test that the writer matches Redact however the input is split into Write calls
+func TestRedactingWriter_Splits(t *testing.T) {
+	inputs := []string{
+		"user=716.566.867-59 company=12.ABC.345/01DE-35\n",
+		"71656686759",
+		"x 22796729000159 y 12ABC34501DE35",
+		"lower 12.abc.345/01de-35 and 12abc34501de35",
+		"run 12.345.678.901-23 x.716.566.867-59 .71656686759",
+		"tail 71656686759-12 716.566.867-59.123 22.796.729/0001-59/0002 716.566.867-59. end",
+		"invalid 716.566.867-58 then valid 71656686759 and a run 7165668675912",
+		"abc71656686759 71656686759x 716.566.867-59",
+		"chave 35190722796729000159550010000001231000001234 fim 22.796.729/0001-59",
+	}
+
+	for _, input := range inputs {
+		expected := Redact(input, RedactOptions{})
+
+		// Every single split point
+		for split := 0; split <= len(input); split++ {
+			var buf bytes.Buffer
+			w := NewRedactingWriter(&buf, RedactOptions{})
+			_, _ = w.Write([]byte(input[:split]))
+			_, _ = w.Write([]byte(input[split:]))
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close() unexpected error: %v", err)
+			}
+			if buf.String() != expected {
+				t.Errorf("split at %d of %q = %q, want %q", split, input, buf.String(), expected)
+			}
+		}
+
+		// One byte at a time
+		var buf bytes.Buffer
+		w := NewRedactingWriter(&buf, RedactOptions{})
+		for i := 0; i < len(input); i++ {
+			if n, err := w.Write([]byte{input[i]}); n != 1 || err != nil {
+				t.Fatalf("Write() = %d, %v, want 1, nil", n, err)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			t.Fatalf("Flush() unexpected error: %v", err)
+		}
+		if buf.String() != expected {
+			t.Errorf("byte-by-byte %q = %q, want %q", input, buf.String(), expected)
+		}
+	}
+}
+
+// Test that the writer does not hold back more than it needs
+func TestRedactingWriter_HoldsBackTail(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewRedactingWriter(&buf, RedactOptions{})
+
+	line := strings.Repeat("log line without documents ", 4)
+	_, _ = w.Write([]byte(line))
+	if got := buf.Len(); got != len(line)-heldBackLength {
+		t.Errorf("written before Flush = %d bytes, want %d", got, len(line)-heldBackLength)
+	}
+
+	_ = w.Flush()
+	if buf.String() != line {
+		t.Errorf("after Flush = %q, want %q", buf.String(), line)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+// Test that errors from the underlying writer are reported and sticky
+func TestRedactingWriter_Error(t *testing.T) {
+	w := NewRedactingWriter(failingWriter{}, RedactOptions{})
+
+	if _, err := w.Write([]byte(strings.Repeat("x", 100))); err == nil {
+		t.Fatal("Write() expected error, got nil")
+	}
+	if _, err := w.Write([]byte("more")); err == nil {
+		t.Error("Write() after failure expected error, got nil")
+	}
+	if err := w.Close(); err == nil {
+		t.Error("Close() after failure expected error, got nil")
+	}
+}
+
+// Benchmark redacting a stream of log lines
+func BenchmarkRedactingWriter(b *testing.B) {
+	line := []byte("2024-01-01 INFO user=716.566.867-59 company=12ABC34501DE35 phone=11987654321\n")
+	w := NewRedactingWriter(io.Discard, RedactOptions{})
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(line)))
+	for i := 0; i < b.N; i++ {
+		_, _ = w.Write(line)
+	}
+}
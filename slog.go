@@ -0,0 +1,110 @@
+package cpfcnpj
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LogValue implements slog.LogValuer so CPFs are masked with MaskGovBR when logged.
+// Wrap the value with Unmasked to log it in full.
+func (c CPF) LogValue() slog.Value {
+	return slog.StringValue(c.Masked())
+}
+
+// LogValue implements slog.LogValuer so CNPJs are masked with MaskRootVisible when logged.
+// Wrap the value with Unmasked to log it in full.
+func (c CNPJ) LogValue() slog.Value {
+	return slog.StringValue(c.Masked())
+}
+
+// LogValue implements slog.LogValuer so TaxIDs are masked like the CPF or CNPJ they hold.
+// Wrap the value with Unmasked to log it in full.
+func (t TaxID) LogValue() slog.Value {
+	return slog.StringValue(t.Masked())
+}
+
+// Unmasked opts a document out of log masking: the returned value logs the formatted document.
+//
+//	logger.Info("payment", "payer", cpfcnpj.Unmasked(cpf))
+//
+// RedactAttr and NewRedactingHandler still mask it, since they only see the logged string.
+func Unmasked(doc Document) slog.LogValuer {
+	return unmaskedDocument{doc: doc}
+}
+
+type unmaskedDocument struct {
+	doc Document
+}
+
+func (u unmaskedDocument) LogValue() slog.Value {
+	return slog.StringValue(u.doc.String())
+}
+
+// RedactAttr returns a function for slog.HandlerOptions.ReplaceAttr that redacts valid documents
+// found in string attributes, including the log message:
+//
+//	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+//		ReplaceAttr: cpfcnpj.RedactAttr(cpfcnpj.RedactOptions{}),
+//	})
+func RedactAttr(opts RedactOptions) func(groups []string, a slog.Attr) slog.Attr {
+	return func(_ []string, a slog.Attr) slog.Attr {
+		return redactAttr(a, opts)
+	}
+}
+
+// NewRedactingHandler wraps h so that valid documents in the message and in string attributes,
+// including attributes nested in groups, are redacted before h sees the record.
+// Use it with handlers that do not support ReplaceAttr.
+func NewRedactingHandler(h slog.Handler, opts RedactOptions) slog.Handler {
+	return &redactingHandler{next: h, opts: opts}
+}
+
+type redactingHandler struct {
+	next slog.Handler
+	opts RedactOptions
+}
+
+func (h *redactingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *redactingHandler) Handle(ctx context.Context, r slog.Record) error {
+	redacted := slog.NewRecord(r.Time, r.Level, Redact(r.Message, h.opts), r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(a, h.opts))
+		return true
+	})
+	return h.next.Handle(ctx, redacted)
+}
+
+func (h *redactingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redacted := make([]slog.Attr, len(attrs))
+	for i, a := range attrs {
+		redacted[i] = redactAttr(a, h.opts)
+	}
+	return &redactingHandler{next: h.next.WithAttrs(redacted), opts: h.opts}
+}
+
+func (h *redactingHandler) WithGroup(name string) slog.Handler {
+	return &redactingHandler{next: h.next.WithGroup(name), opts: h.opts}
+}
+
+// redactAttr resolves a and redacts its string value, recursing into groups.
+func redactAttr(a slog.Attr, opts RedactOptions) slog.Attr {
+	a.Value = a.Value.Resolve()
+
+	//exhaustive:ignore only strings and groups can hold a document
+	switch a.Value.Kind() {
+	case slog.KindString:
+		a.Value = slog.StringValue(Redact(a.Value.String(), opts))
+	case slog.KindGroup:
+		group := a.Value.Group()
+		redacted := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			redacted[i] = redactAttr(ga, opts)
+		}
+		a.Value = slog.GroupValue(redacted...)
+	}
+
+	return a
+}
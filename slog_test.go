@@ -0,0 +1,111 @@
+package cpfcnpj
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// Test that documents are masked by default when logged
+func TestLogValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected string
+	}{
+		{"CPF is masked", CPF("71656686759"), "doc=***.566.867-**"},
+		{"CNPJ is masked", CNPJ("12ABC34501DE35"), "doc=12.ABC.345/****-**"},
+		{"TaxID CPF is masked", TaxID("71656686759"), "doc=***.566.867-**"},
+		{"TaxID CNPJ is masked", TaxID("22796729000159"), "doc=22.796.729/****-**"},
+		{"Unmasked CPF", Unmasked(CPF("71656686759")), "doc=716.566.867-59"},
+		{"Unmasked TaxID", Unmasked(TaxID("12ABC34501DE35")), "doc=12.ABC.345/01DE-35"},
+		{"Unmasked CNPJ", Unmasked(CNPJ("22796729000159")), "doc=22.796.729/0001-59"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+				ReplaceAttr: dropTime,
+			}))
+			logger.Info("m", "doc", tt.value)
+
+			if !strings.Contains(buf.String(), tt.expected) {
+				t.Errorf("log output = %q, want it to contain %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+// Test redaction of plain strings through ReplaceAttr
+func TestRedactAttr(t *testing.T) {
+	redact := RedactAttr(RedactOptions{})
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			return redact(groups, dropTime(groups, a))
+		},
+	}))
+	logger.Info("payment from 716.566.867-59",
+		"payee", "22796729000159",
+		slog.Group("req", "body", `{"cnpj":"12.ABC.345/01DE-35"}`),
+		"count", 3,
+	)
+
+	out := buf.String()
+	for _, leaked := range []string{"716.566.867-59", "22796729000159", "12.ABC.345/01DE-35"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("log output %q leaks %q", out, leaked)
+		}
+	}
+	for _, want := range []string{"***.566.867-**", "**7967290001**", "**.ABC.345/01DE-**", "count=3"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log output %q does not contain %q", out, want)
+		}
+	}
+}
+
+// Test redaction through the handler wrapper
+func TestRedactingHandler(t *testing.T) {
+	var buf bytes.Buffer
+	inner := slog.NewJSONHandler(&buf, &slog.HandlerOptions{ReplaceAttr: dropTime})
+	logger := slog.New(NewRedactingHandler(inner, RedactOptions{Style: MaskLastVisible(2)}))
+
+	logger.With("client", "716.566.867-59").
+		WithGroup("req").
+		Info("lookup 22.796.729/0001-59",
+			"doc", "71656686759",
+			slog.Group("nested", "cnpj", "12ABC34501DE35"),
+			"unmasked", Unmasked(CPF("64844696793")),
+			"invalid", "716.566.867-58",
+		)
+
+	expected := `{"level":"INFO","msg":"lookup **.***.***/****-59","client":"***.***.***-59",` +
+		`"req":{"doc":"*********59","nested":{"cnpj":"************35"},"unmasked":"***.***.***-93",` +
+		`"invalid":"716.566.867-58"}}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("log output =\n%s\nwant\n%s", buf.String(), expected)
+	}
+}
+
+// Test that the wrapper forwards Enabled to the wrapped handler
+func TestRedactingHandler_Enabled(t *testing.T) {
+	inner := slog.NewTextHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: slog.LevelWarn})
+	h := NewRedactingHandler(inner, RedactOptions{})
+
+	if h.Enabled(t.Context(), slog.LevelInfo) {
+		t.Error("Enabled(Info) = true, want false")
+	}
+	if !h.Enabled(t.Context(), slog.LevelError) {
+		t.Error("Enabled(Error) = false, want true")
+	}
+}
+
+func dropTime(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) == 0 && a.Key == slog.TimeKey {
+		return slog.Attr{}
+	}
+	return a
+}
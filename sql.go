@@ -0,0 +1,223 @@
+package cpfcnpj
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+// Database errors
+var (
+	ErrNullValue = errors.New("cannot scan NULL into a non-nullable document, " +
+		"use NullCPF, NullCNPJ or NullTaxID")
+	ErrUnsupportedScanType = errors.New("unsupported database type for document")
+)
+
+// Compile-time interface checks
+var (
+	_ sql.Scanner   = (*CPF)(nil)
+	_ driver.Valuer = CPF("")
+	_ sql.Scanner   = (*CNPJ)(nil)
+	_ driver.Valuer = CNPJ("")
+	_ sql.Scanner   = (*NullCPF)(nil)
+	_ driver.Valuer = NullCPF{}
+	_ sql.Scanner   = (*NullCNPJ)(nil)
+	_ driver.Valuer = NullCNPJ{}
+	_ sql.Scanner   = (*TaxID)(nil)
+	_ driver.Valuer = TaxID("")
+	_ sql.Scanner   = (*NullTaxID)(nil)
+	_ driver.Valuer = NullTaxID{}
+)
+
+// Scan implements sql.Scanner. It accepts string, []byte and int64 columns and validates
+// the value with NewCpf. Integer columns get their leading zeros restored, so 3167158085
+// scans as "03167158085". NULL is rejected; use NullCPF for nullable columns.
+func (c *CPF) Scan(src any) error {
+	text, err := scanDocumentText(src, "CPF", CPFLength)
+	if err != nil {
+		return err
+	}
+
+	cpf, err := NewCpf(text)
+	if err != nil {
+		return err
+	}
+	*c = cpf
+	return nil
+}
+
+// Value implements driver.Valuer, storing the raw digits.
+// The value is validated first, so an invalid CPF is never written.
+func (c CPF) Value() (driver.Value, error) {
+	cpf, err := NewCpf(string(c))
+	if err != nil {
+		return nil, err
+	}
+	return cpf.Raw(), nil
+}
+
+// Scan implements sql.Scanner. It accepts string, []byte and int64 columns and validates
+// the value with NewCnpj. Integer columns get their leading zeros restored for numeric CNPJs.
+// NULL is rejected; use NullCNPJ for nullable columns.
+func (c *CNPJ) Scan(src any) error {
+	text, err := scanDocumentText(src, "CNPJ", CNPJLength)
+	if err != nil {
+		return err
+	}
+
+	cnpj, err := NewCnpj(text)
+	if err != nil {
+		return err
+	}
+	*c = cnpj
+	return nil
+}
+
+// Value implements driver.Valuer, storing the raw characters.
+// The value is validated first, so an invalid CNPJ is never written.
+func (c CNPJ) Value() (driver.Value, error) {
+	cnpj, err := NewCnpj(string(c))
+	if err != nil {
+		return nil, err
+	}
+	return cnpj.Raw(), nil
+}
+
+// NullCPF represents a CPF that may be NULL, in the style of sql.NullString.
+type NullCPF struct {
+	CPF   CPF
+	Valid bool // Valid is true if CPF is not NULL
+}
+
+// Scan implements sql.Scanner.
+func (n *NullCPF) Scan(src any) error {
+	if src == nil {
+		n.CPF, n.Valid = "", false
+		return nil
+	}
+
+	if err := n.CPF.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (n NullCPF) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.CPF.Value()
+}
+
+// NullCNPJ represents a CNPJ that may be NULL, in the style of sql.NullString.
+type NullCNPJ struct {
+	CNPJ  CNPJ
+	Valid bool // Valid is true if CNPJ is not NULL
+}
+
+// Scan implements sql.Scanner.
+func (n *NullCNPJ) Scan(src any) error {
+	if src == nil {
+		n.CNPJ, n.Valid = "", false
+		return nil
+	}
+
+	if err := n.CNPJ.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (n NullCNPJ) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.CNPJ.Value()
+}
+
+// Scan implements sql.Scanner. It accepts string and []byte columns and validates the value
+// with Parse. Integer columns are rejected: without the leading zeros a numeric column drops,
+// a CPF cannot be told apart from a CNPJ. NULL is rejected; use NullTaxID for nullable columns.
+func (t *TaxID) Scan(src any) error {
+	if _, ok := src.(int64); ok {
+		return fmt.Errorf("TaxID cannot be scanned from an integer column, CPF and CNPJ are ambiguous: %w",
+			ErrUnsupportedScanType)
+	}
+	text, err := scanDocumentText(src, "TaxID", 0)
+	if err != nil {
+		return err
+	}
+
+	id, err := NewTaxID(text)
+	if err != nil {
+		return err
+	}
+	*t = id
+	return nil
+}
+
+// Value implements driver.Valuer, storing the raw characters.
+// The value is validated first, so an invalid TaxID is never written.
+func (t TaxID) Value() (driver.Value, error) {
+	id, err := NewTaxID(string(t))
+	if err != nil {
+		return nil, err
+	}
+	return id.Raw(), nil
+}
+
+// NullTaxID represents a TaxID that may be NULL, in the style of sql.NullString.
+type NullTaxID struct {
+	TaxID TaxID
+	Valid bool // Valid is true if TaxID is not NULL
+}
+
+// Scan implements sql.Scanner.
+func (n *NullTaxID) Scan(src any) error {
+	if src == nil {
+		n.TaxID, n.Valid = "", false
+		return nil
+	}
+
+	if err := n.TaxID.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (n NullTaxID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return n.TaxID.Value()
+}
+
+// scanDocumentText converts a database value into text for validation.
+// Integers are zero-padded to width to restore leading zeros lost by numeric columns.
+func scanDocumentText(src any, name string, width int) (string, error) {
+	switch v := src.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case int64:
+		if v < 0 {
+			return "", fmt.Errorf("%s cannot be negative, got %d: %w", name, v, ErrUnsupportedScanType)
+		}
+		return fmt.Sprintf("%0*d", width, v), nil
+	case nil:
+		return "", fmt.Errorf("%s: %w", name, ErrNullValue)
+	default:
+		return "", fmt.Errorf("%s cannot be scanned from %T: %w", name, src, ErrUnsupportedScanType)
+	}
+}
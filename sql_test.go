@@ -0,0 +1,182 @@
+package cpfcnpj
+
+import (
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+// Test scanning CPF values from database columns
+func TestCPFScan(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         any
+		expected    CPF
+		expectedErr error
+	}{
+		{"String column", "71656686759", "71656686759", nil},
+		{"Formatted string column", "716.566.867-59", "71656686759", nil},
+		{"Bytes column", []byte("71656686759"), "71656686759", nil},
+		{"Integer column", int64(71656686759), "71656686759", nil},
+		{"Integer column restores leading zero", int64(3167158085), "03167158085", nil},
+
+		// Errors
+		{"Invalid checksum", "71656686758", "", ErrCPFInvalidChecksum},
+		{"Integer too large", int64(716566867590), "", ErrCPFInvalidLength},
+		{"Negative integer", int64(-1), "", ErrUnsupportedScanType},
+		{"NULL", nil, "", ErrNullValue},
+		{"Unsupported type", 3.14, "", ErrUnsupportedScanType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cpf CPF
+			err := cpf.Scan(tt.src)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("CPF.Scan(%v) error = %v, want %v", tt.src, err, tt.expectedErr)
+			}
+			if cpf != tt.expected {
+				t.Errorf("CPF.Scan(%v) = %q, want %q", tt.src, cpf, tt.expected)
+			}
+		})
+	}
+}
+
+// Test scanning CNPJ values from database columns
+func TestCNPJScan(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         any
+		expected    CNPJ
+		expectedErr error
+	}{
+		{"String column", "22796729000159", "22796729000159", nil},
+		{"Alphanumeric string column", "12ABC34501DE35", "12ABC34501DE35", nil},
+		{"Bytes column", []byte("12.abc.345/01de-35"), "12ABC34501DE35", nil},
+		{"Integer column", int64(22796729000159), "22796729000159", nil},
+		{"Integer column Banco do Brasil", int64(191), "00000000000191", nil},
+
+		// Errors
+		{"Invalid checksum", "12ABC34501DE99", "", ErrCNPJInvalidChecksum},
+		{"NULL", nil, "", ErrNullValue},
+		{"Unsupported type", true, "", ErrUnsupportedScanType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cnpj CNPJ
+			err := cnpj.Scan(tt.src)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("CNPJ.Scan(%v) error = %v, want %v", tt.src, err, tt.expectedErr)
+			}
+			if err == nil && cnpj != tt.expected {
+				t.Errorf("CNPJ.Scan(%v) = %q, want %q", tt.src, cnpj, tt.expected)
+			}
+		})
+	}
+}
+
+// Test Value validation before writing
+func TestDocumentValue(t *testing.T) {
+	tests := []struct {
+		name        string
+		valuer      driver.Valuer
+		expected    driver.Value
+		expectedErr error
+	}{
+		{"Valid CPF", CPF("71656686759"), "71656686759", nil},
+		{"Valid CNPJ", CNPJ("12ABC34501DE35"), "12ABC34501DE35", nil},
+		{"Invalid CPF", CPF("12345678901"), nil, ErrCPFInvalidChecksum},
+		{"Zero CNPJ", CNPJ(""), nil, ErrCNPJInvalidLength},
+		{"Valid NullCPF", NullCPF{CPF: "71656686759", Valid: true}, "71656686759", nil},
+		{"NULL NullCPF", NullCPF{}, nil, nil},
+		{"Valid NullCNPJ", NullCNPJ{CNPJ: "22796729000159", Valid: true}, "22796729000159", nil},
+		{"NULL NullCNPJ", NullCNPJ{}, nil, nil},
+		{"Invalid NullCNPJ", NullCNPJ{CNPJ: "123", Valid: true}, nil, ErrCNPJInvalidLength},
+		{"Valid TaxID", TaxID("71656686759"), "71656686759", nil},
+		{"Zero TaxID", TaxID(""), nil, ErrUnknownDocument},
+		{"Valid NullTaxID", NullTaxID{TaxID: "12ABC34501DE35", Valid: true}, "12ABC34501DE35", nil},
+		{"NULL NullTaxID", NullTaxID{}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.valuer.Value()
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Value() error = %v, want %v", err, tt.expectedErr)
+			}
+			if got != tt.expected {
+				t.Errorf("Value() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+// Test nullable wrappers
+func TestNullDocumentScan(t *testing.T) {
+	var ncpf NullCPF
+	if err := ncpf.Scan(nil); err != nil || ncpf.Valid {
+		t.Errorf("NullCPF.Scan(nil) = %+v, %v, want invalid, nil", ncpf, err)
+	}
+	if err := ncpf.Scan(int64(3167158085)); err != nil || !ncpf.Valid || ncpf.CPF != "03167158085" {
+		t.Errorf("NullCPF.Scan(int64) = %+v, %v", ncpf, err)
+	}
+	if err := ncpf.Scan("111.111.111-11"); !errors.Is(err, ErrAllSameDigits) || ncpf.Valid {
+		t.Errorf("NullCPF.Scan(invalid) = %+v, %v", ncpf, err)
+	}
+
+	var ncnpj NullCNPJ
+	if err := ncnpj.Scan(nil); err != nil || ncnpj.Valid {
+		t.Errorf("NullCNPJ.Scan(nil) = %+v, %v, want invalid, nil", ncnpj, err)
+	}
+	if err := ncnpj.Scan([]byte("12ABC34501DE35")); err != nil || !ncnpj.Valid || ncnpj.CNPJ != "12ABC34501DE35" {
+		t.Errorf("NullCNPJ.Scan([]byte) = %+v, %v", ncnpj, err)
+	}
+	if err := ncnpj.Scan("12ABC34501DE99"); !errors.Is(err, ErrCNPJInvalidChecksum) || ncnpj.Valid {
+		t.Errorf("NullCNPJ.Scan(invalid) = %+v, %v", ncnpj, err)
+	}
+}
+
+// Test scanning TaxID values, which detect the document kind
+func TestTaxIDScan(t *testing.T) {
+	tests := []struct {
+		name         string
+		src          any
+		expected     TaxID
+		expectedKind Kind
+		expectedErr  error
+	}{
+		{"CPF string column", "716.566.867-59", "71656686759", KindCPF, nil},
+		{"CNPJ bytes column", []byte("12.abc.345/01de-35"), "12ABC34501DE35", KindCNPJAlphanumeric, nil},
+
+		// Errors
+		{"Invalid checksum", "22796729000158", "", KindUnknown, ErrCNPJInvalidChecksum},
+		{"Integer column is ambiguous", int64(71656686759), "", KindUnknown, ErrUnsupportedScanType},
+		{"NULL", nil, "", KindUnknown, ErrNullValue},
+		{"Unsupported type", 3.14, "", KindUnknown, ErrUnsupportedScanType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var id TaxID
+			err := id.Scan(tt.src)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("TaxID.Scan(%v) error = %v, want %v", tt.src, err, tt.expectedErr)
+			}
+			if id != tt.expected || id.Kind() != tt.expectedKind {
+				t.Errorf("TaxID.Scan(%v) = %q (%v), want %q (%v)", tt.src, id, id.Kind(), tt.expected, tt.expectedKind)
+			}
+		})
+	}
+
+	var nid NullTaxID
+	if err := nid.Scan(nil); err != nil || nid.Valid {
+		t.Errorf("NullTaxID.Scan(nil) = %+v, %v, want invalid, nil", nid, err)
+	}
+	if err := nid.Scan("22796729000159"); err != nil || !nid.Valid || nid.TaxID.Kind() != KindCNPJNumeric {
+		t.Errorf("NullTaxID.Scan(string) = %+v, %v", nid, err)
+	}
+	if err := nid.Scan("123"); !errors.Is(err, ErrUnknownDocument) || nid.Valid {
+		t.Errorf("NullTaxID.Scan(invalid) = %+v, %v", nid, err)
+	}
+}
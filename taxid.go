@@ -0,0 +1,101 @@
+package cpfcnpj
+
+// TaxID holds either a CPF or a CNPJ, for values such as a PIX payee or an invoice recipient
+// that may be a person or a company. The kind follows from the length of the raw value:
+// 11 characters for a CPF and 14 for a CNPJ.
+//
+// Create one with NewTaxID, or convert a validated document with TaxID(cpf) or TaxID(cnpj).
+// The zero value is an empty TaxID of KindUnknown.
+type TaxID string
+
+// NewTaxID validates s with Parse and returns it as a TaxID.
+func NewTaxID(s string) (TaxID, error) {
+	doc, err := Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return TaxID(doc.Raw()), nil
+}
+
+// Kind returns KindCPF, KindCNPJNumeric or KindCNPJAlphanumeric, or KindUnknown for the zero
+// value and values of any other length.
+func (t TaxID) Kind() Kind {
+	switch len(t) {
+	case CPFLength:
+		return KindCPF
+	case CNPJLength:
+		return CNPJ(t).Kind()
+	default:
+		return KindUnknown
+	}
+}
+
+// AsCPF returns the TaxID as a CPF and reports whether it holds one.
+func (t TaxID) AsCPF() (CPF, bool) {
+	if len(t) != CPFLength {
+		return "", false
+	}
+	return CPF(t), true
+}
+
+// AsCNPJ returns the TaxID as a CNPJ and reports whether it holds one.
+func (t TaxID) AsCNPJ() (CNPJ, bool) {
+	if len(t) != CNPJLength {
+		return "", false
+	}
+	return CNPJ(t), true
+}
+
+// Document returns the CPF or CNPJ held by the TaxID, or nil for KindUnknown.
+func (t TaxID) Document() Document {
+	switch len(t) {
+	case CPFLength:
+		return CPF(t)
+	case CNPJLength:
+		return CNPJ(t)
+	default:
+		return nil
+	}
+}
+
+// IsZero reports whether the TaxID is empty.
+func (t TaxID) IsZero() bool {
+	return t == ""
+}
+
+// String returns the document formatted as a CPF or a CNPJ.
+// Values of any other length are returned as-is.
+func (t TaxID) String() string {
+	if doc := t.Document(); doc != nil {
+		return doc.String()
+	}
+	return string(t)
+}
+
+// Raw returns the document without formatting characters.
+func (t TaxID) Raw() string {
+	return string(t)
+}
+
+// Masked returns the document masked like CPF.Masked or CNPJ.Masked
+// (e.g. "***.566.867-**" or "22.796.729/****-**").
+func (t TaxID) Masked() string {
+	if doc := t.Document(); doc != nil {
+		return doc.Masked()
+	}
+	return maskAll(string(t))
+}
+
+// MaskedWith returns the formatted document with the characters hidden by style replaced by '*'.
+func (t TaxID) MaskedWith(style MaskStyle) string {
+	if doc := t.Document(); doc != nil {
+		return doc.MaskedWith(style)
+	}
+	return maskAll(string(t))
+}
+
+// MatchesMasked reports whether a masked value is consistent with the document.
+func (t TaxID) MatchesMasked(masked string) bool {
+	doc := t.Document()
+	return doc != nil && doc.MatchesMasked(masked)
+}
@@ -0,0 +1,129 @@
+package cpfcnpj
+
+import (
+	"errors"
+	"testing"
+)
+
+// Test TaxID construction and accessors for every kind
+func TestNewTaxID(t *testing.T) {
+	tests := []struct {
+		name              string
+		input             string
+		expectedRaw       string
+		expectedFormatted string
+		expectedMasked    string
+		expectedKind      Kind
+		expectedErr       error
+	}{
+		{
+			name:              "CPF",
+			input:             "716.566.867-59",
+			expectedRaw:       "71656686759",
+			expectedFormatted: "716.566.867-59",
+			expectedMasked:    "***.566.867-**",
+			expectedKind:      KindCPF,
+		},
+		{
+			name:              "Numeric CNPJ",
+			input:             "22796729000159",
+			expectedRaw:       "22796729000159",
+			expectedFormatted: "22.796.729/0001-59",
+			expectedMasked:    "22.796.729/****-**",
+			expectedKind:      KindCNPJNumeric,
+		},
+		{
+			name:              "Alphanumeric CNPJ",
+			input:             "12.abc.345/01de-35",
+			expectedRaw:       "12ABC34501DE35",
+			expectedFormatted: "12.ABC.345/01DE-35",
+			expectedMasked:    "12.ABC.345/****-**",
+			expectedKind:      KindCNPJAlphanumeric,
+		},
+
+		// Errors
+		{name: "Invalid CPF", input: "716.566.867-58", expectedErr: ErrCPFInvalidChecksum},
+		{name: "Invalid CNPJ", input: "12ABC34501DE99", expectedErr: ErrCNPJInvalidChecksum},
+		{name: "Unknown length", input: "123", expectedErr: ErrUnknownDocument},
+		{name: "Empty", input: "", expectedErr: ErrUnknownDocument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := NewTaxID(tt.input)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("NewTaxID(%q) error = %v, want %v", tt.input, err, tt.expectedErr)
+			}
+			if err != nil {
+				if !id.IsZero() {
+					t.Errorf("NewTaxID(%q) = %q on error, want zero value", tt.input, id)
+				}
+				return
+			}
+
+			if id.Raw() != tt.expectedRaw {
+				t.Errorf("Raw() = %q, want %q", id.Raw(), tt.expectedRaw)
+			}
+			if id.String() != tt.expectedFormatted {
+				t.Errorf("String() = %q, want %q", id.String(), tt.expectedFormatted)
+			}
+			if id.Masked() != tt.expectedMasked {
+				t.Errorf("Masked() = %q, want %q", id.Masked(), tt.expectedMasked)
+			}
+			if id.Kind() != tt.expectedKind {
+				t.Errorf("Kind() = %v, want %v", id.Kind(), tt.expectedKind)
+			}
+			if !id.MatchesMasked(tt.expectedMasked) {
+				t.Errorf("MatchesMasked(%q) = false", tt.expectedMasked)
+			}
+			if id.IsZero() {
+				t.Error("IsZero() = true for a valid TaxID")
+			}
+		})
+	}
+}
+
+// Test conversions between TaxID and the concrete document types
+func TestTaxID_As(t *testing.T) {
+	cpfID := TaxID(CPF("71656686759"))
+	if cpf, ok := cpfID.AsCPF(); !ok || cpf != "71656686759" {
+		t.Errorf("AsCPF() = %q, %v, want the CPF", cpf, ok)
+	}
+	if cnpj, ok := cpfID.AsCNPJ(); ok || cnpj != "" {
+		t.Errorf("AsCNPJ() on a CPF = %q, %v, want zero, false", cnpj, ok)
+	}
+	if _, ok := cpfID.Document().(CPF); !ok {
+		t.Errorf("Document() = %T, want CPF", cpfID.Document())
+	}
+
+	cnpjID := TaxID(CNPJ("12ABC34501DE35"))
+	if cnpj, ok := cnpjID.AsCNPJ(); !ok || cnpj.Branch() != "01DE" {
+		t.Errorf("AsCNPJ() = %q, %v, want the CNPJ", cnpj, ok)
+	}
+	if _, ok := cnpjID.AsCPF(); ok {
+		t.Error("AsCPF() on a CNPJ = true")
+	}
+
+	var zero TaxID
+	if !zero.IsZero() || zero.Kind() != KindUnknown || zero.Document() != nil || zero.String() != "" ||
+		zero.MatchesMasked("***.***.***-**") {
+		t.Errorf("zero TaxID = {Kind: %v, Document: %v, String: %q}", zero.Kind(), zero.Document(), zero.String())
+	}
+	if _, ok := zero.AsCPF(); ok {
+		t.Error("zero AsCPF() = true")
+	}
+}
+
+// Test that malformed values never leak when masked
+func TestTaxID_MaskedMalformed(t *testing.T) {
+	id := TaxID("716566867")
+	if got := id.Masked(); got != "*********" {
+		t.Errorf("Masked() = %q, want all characters hidden", got)
+	}
+	if got := id.MaskedWith(MaskLastVisible(2)); got != "*********" {
+		t.Errorf("MaskedWith() = %q, want all characters hidden", got)
+	}
+	if got := TaxID("22796729000159").MaskedWith(MaskGovBR); got != "**.796.729/0001-**" {
+		t.Errorf("MaskedWith(MaskGovBR) = %q, want %q", got, "**.796.729/0001-**")
+	}
+}
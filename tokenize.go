@@ -0,0 +1,180 @@
+package cpfcnpj
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Tokenization errors
+var (
+	ErrInvalidTokenKey = errors.New("invalid tokenization key")
+	ErrMalformedToken  = errors.New("malformed document token")
+	ErrUnknownTokenKey = errors.New("document token uses an unknown key")
+)
+
+// MinTokenKeySize is the minimum length in bytes of a TokenKey secret.
+const MinTokenKeySize = 32
+
+// tokenMarker separates the kind prefix from the key ID in a token: "cpf_tk_<key id>_<mac>".
+const tokenMarker = "_tk_"
+
+// TokenKey is a secret used by Tokenizer. The ID is embedded in every token so that tokens made
+// with a retired key can still be matched during rotation; it must be non-empty and alphanumeric.
+type TokenKey struct {
+	ID     string
+	Secret []byte
+}
+
+// Tokenizer turns documents into stable keyed pseudonyms (HMAC-SHA256 of the raw document),
+// so datasets can be joined on a document without storing it.
+// A Tokenizer is safe for concurrent use.
+type Tokenizer struct {
+	current string
+	keys    map[string][]byte
+}
+
+// NewTokenizer returns a Tokenizer that creates tokens with current and also matches tokens
+// created with any of the previous keys.
+func NewTokenizer(current TokenKey, previous ...TokenKey) (*Tokenizer, error) {
+	t := &Tokenizer{
+		current: current.ID,
+		keys:    make(map[string][]byte, 1+len(previous)),
+	}
+
+	for _, key := range append([]TokenKey{current}, previous...) {
+		if err := key.validate(); err != nil {
+			return nil, err
+		}
+		if _, ok := t.keys[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key ID %q: %w", key.ID, ErrInvalidTokenKey)
+		}
+		t.keys[key.ID] = append([]byte(nil), key.Secret...)
+	}
+
+	return t, nil
+}
+
+// Token returns the token for doc made with the current key, e.g. "cpf_tk_2024_Zm9v...".
+// It is computed on doc.Raw(), so formatting never changes the result.
+// The document is validated first; invalid documents return the validation error.
+func (t *Tokenizer) Token(doc Document) (string, error) {
+	if err := validateDocument(doc); err != nil {
+		return "", err
+	}
+	return t.token(t.current, doc), nil
+}
+
+// Matches reports whether token was created from doc with any key known to t.
+// The MAC comparison is constant-time.
+func (t *Tokenizer) Matches(doc Document, token string) bool {
+	if validateDocument(doc) != nil {
+		return false
+	}
+
+	keyID, err := TokenKeyID(token)
+	if err != nil {
+		return false
+	}
+	if _, ok := t.keys[keyID]; !ok {
+		return false
+	}
+
+	return hmac.Equal([]byte(t.token(keyID, doc)), []byte(token))
+}
+
+// NeedsRotation reports whether token was created with a key other than the current one.
+// Tokens with a malformed or unknown key ID return an error.
+func (t *Tokenizer) NeedsRotation(token string) (bool, error) {
+	keyID, err := TokenKeyID(token)
+	if err != nil {
+		return false, err
+	}
+	if _, ok := t.keys[keyID]; !ok {
+		return false, fmt.Errorf("key ID %q: %w", keyID, ErrUnknownTokenKey)
+	}
+	return keyID != t.current, nil
+}
+
+// TokenKeyID returns the ID of the key a token was created with.
+func TokenKeyID(token string) (string, error) {
+	if len(token) > MaxInputSize {
+		return "", fmt.Errorf("token exceeds maximum size of %d bytes: %w", MaxInputSize, ErrMalformedToken)
+	}
+
+	prefix, rest, ok := strings.Cut(token, tokenMarker)
+	if !ok || (prefix != tokenPrefix(KindCPF) && prefix != tokenPrefix(KindCNPJNumeric)) {
+		return "", ErrMalformedToken
+	}
+
+	keyID, mac, ok := strings.Cut(rest, "_")
+	if !ok || !isValidKeyID(keyID) || mac == "" {
+		return "", ErrMalformedToken
+	}
+
+	return keyID, nil
+}
+
+// token computes the token for doc with the key identified by keyID.
+func (t *Tokenizer) token(keyID string, doc Document) string {
+	prefix := tokenPrefix(doc.Kind())
+
+	mac := hmac.New(sha256.New, t.keys[keyID])
+	mac.Write([]byte(prefix))
+	mac.Write([]byte{':'})
+	mac.Write([]byte(doc.Raw()))
+
+	return prefix + tokenMarker + keyID + "_" + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// tokenPrefix returns the token prefix for a document kind. Numeric and alphanumeric CNPJs
+// share the "cnpj" prefix since they identify the same kind of entity.
+func tokenPrefix(kind Kind) string {
+	if kind.IsCNPJ() {
+		return "cnpj"
+	}
+	return "cpf"
+}
+
+// validate checks the key ID and secret length.
+func (k TokenKey) validate() error {
+	if !isValidKeyID(k.ID) {
+		return fmt.Errorf("key ID must be non-empty and alphanumeric, got %q: %w", k.ID, ErrInvalidTokenKey)
+	}
+	if len(k.Secret) < MinTokenKeySize {
+		return fmt.Errorf("key %q secret must have at least %d bytes, got %d: %w", k.ID, MinTokenKeySize,
+			len(k.Secret), ErrInvalidTokenKey)
+	}
+	return nil
+}
+
+// isValidKeyID reports whether id is a non-empty alphanumeric string.
+func isValidKeyID(id string) bool {
+	if id == "" {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if !isAlphanumericByte(id[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateDocument revalidates doc with the constructor for its kind.
+func validateDocument(doc Document) error {
+	if doc == nil {
+		return ErrUnknownDocument
+	}
+
+	var err error
+	if doc.Kind().IsCNPJ() {
+		_, err = NewCnpj(doc.Raw())
+	} else {
+		_, err = NewCpf(doc.Raw())
+	}
+	return err
+}
@@ -0,0 +1,232 @@
+package cpfcnpj
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+var (
+	testKey2024 = TokenKey{ID: "k2024", Secret: bytes.Repeat([]byte{0x24}, MinTokenKeySize)}
+	testKey2025 = TokenKey{ID: "k2025", Secret: bytes.Repeat([]byte{0x25}, MinTokenKeySize)}
+)
+
+// Test token format and stability
+func TestTokenizer_Token(t *testing.T) {
+	tok, err := NewTokenizer(testKey2024)
+	if err != nil {
+		t.Fatalf("NewTokenizer() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		doc            Document
+		expectedPrefix string
+	}{
+		{"CPF", CPF("71656686759"), "cpf_tk_k2024_"},
+		{"Numeric CNPJ", CNPJ("22796729000159"), "cnpj_tk_k2024_"},
+		{"Alphanumeric CNPJ", CNPJ("12ABC34501DE35"), "cnpj_tk_k2024_"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := tok.Token(tt.doc)
+			if err != nil {
+				t.Fatalf("Token() unexpected error: %v", err)
+			}
+			if !strings.HasPrefix(token, tt.expectedPrefix) {
+				t.Errorf("Token() = %q, want prefix %q", token, tt.expectedPrefix)
+			}
+			if again, _ := tok.Token(tt.doc); again != token {
+				t.Errorf("Token() not stable: %q != %q", again, token)
+			}
+			if !tok.Matches(tt.doc, token) {
+				t.Errorf("Matches(%q, %q) = false, want true", tt.doc.Raw(), token)
+			}
+		})
+	}
+}
+
+// Test that formatting never changes the token
+func TestTokenizer_IgnoresFormatting(t *testing.T) {
+	tok, _ := NewTokenizer(testKey2024)
+
+	formatted, _ := NewCpf("716.566.867-59")
+	raw, _ := NewCpf("71656686759")
+	parsed, _ := Parse(" 716 566 867 59 ")
+
+	t1, _ := tok.Token(formatted)
+	t2, _ := tok.Token(raw)
+	t3, _ := tok.Token(parsed)
+	if t1 != t2 || t2 != t3 {
+		t.Errorf("tokens differ by formatting: %q, %q, %q", t1, t2, t3)
+	}
+
+	const expected = "cpf_tk_k2024_"
+	if !strings.HasPrefix(t1, expected) || len(t1) != len(expected)+43 {
+		t.Errorf("Token() = %q, want %q followed by 43 base64url characters", t1, expected)
+	}
+}
+
+// Test that different keys and documents produce different tokens
+func TestTokenizer_Distinct(t *testing.T) {
+	tok2024, _ := NewTokenizer(testKey2024)
+	tok2025, _ := NewTokenizer(testKey2025)
+
+	a, _ := tok2024.Token(CPF("71656686759"))
+	b, _ := tok2024.Token(CPF("64844696793"))
+	c, _ := tok2025.Token(CPF("71656686759"))
+
+	if a == b {
+		t.Error("different documents produced the same token")
+	}
+	if strings.TrimPrefix(a, "cpf_tk_k2024_") == strings.TrimPrefix(c, "cpf_tk_k2025_") {
+		t.Error("different keys produced the same MAC")
+	}
+}
+
+// Test matching tokens across a key rotation
+func TestTokenizer_Rotation(t *testing.T) {
+	old, _ := NewTokenizer(testKey2024)
+	rotated, err := NewTokenizer(testKey2025, testKey2024)
+	if err != nil {
+		t.Fatalf("NewTokenizer() unexpected error: %v", err)
+	}
+
+	doc := CNPJ("12ABC34501DE35")
+	oldToken, _ := old.Token(doc)
+	newToken, _ := rotated.Token(doc)
+
+	if !strings.HasPrefix(newToken, "cnpj_tk_k2025_") {
+		t.Errorf("rotated Token() = %q, want the current key", newToken)
+	}
+	if !rotated.Matches(doc, oldToken) || !rotated.Matches(doc, newToken) {
+		t.Error("rotated tokenizer must match tokens from both keys")
+	}
+	if old.Matches(doc, newToken) {
+		t.Error("old tokenizer must not match tokens from an unknown key")
+	}
+
+	if needs, err := rotated.NeedsRotation(oldToken); err != nil || !needs {
+		t.Errorf("NeedsRotation(old token) = %v, %v, want true, nil", needs, err)
+	}
+	if needs, err := rotated.NeedsRotation(newToken); err != nil || needs {
+		t.Errorf("NeedsRotation(new token) = %v, %v, want false, nil", needs, err)
+	}
+	if _, err := old.NeedsRotation(newToken); !errors.Is(err, ErrUnknownTokenKey) {
+		t.Errorf("NeedsRotation(unknown key) error = %v, want %v", err, ErrUnknownTokenKey)
+	}
+}
+
+// Test tokens that must not match
+func TestTokenizer_Mismatch(t *testing.T) {
+	tok, _ := NewTokenizer(testKey2024)
+	token, _ := tok.Token(CPF("71656686759"))
+
+	tests := []struct {
+		name  string
+		doc   Document
+		token string
+	}{
+		{"Other document", CPF("64844696793"), token},
+		{"Tampered MAC", CPF("71656686759"), token[:len(token)-1] + "A"},
+		{"Kind prefix swapped", CPF("71656686759"), "cnpj" + strings.TrimPrefix(token, "cpf")},
+		{"Invalid document", CPF("71656686758"), token},
+		{"Nil document", nil, token},
+		{"Empty token", CPF("71656686759"), ""},
+		{"Missing MAC", CPF("71656686759"), "cpf_tk_k2024_"},
+		{"Unknown prefix", CPF("71656686759"), "rg_tk_k2024_abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tok.Matches(tt.doc, tt.token) {
+				t.Errorf("Matches(%v, %q) = true, want false", tt.doc, tt.token)
+			}
+		})
+	}
+}
+
+// Test key ID extraction from tokens
+func TestTokenKeyID(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       string
+		expectedID  string
+		expectedErr error
+	}{
+		{"CPF token", "cpf_tk_k2024_abc", "k2024", nil},
+		{"CNPJ token", "cnpj_tk_v2_abc", "v2", nil},
+		{"No marker", "cpf_k2024_abc", "", ErrMalformedToken},
+		{"Empty key ID", "cpf_tk__abc", "", ErrMalformedToken},
+		{"No MAC", "cpf_tk_k2024", "", ErrMalformedToken},
+		{"Bad prefix", "doc_tk_k2024_abc", "", ErrMalformedToken},
+		{"Oversized", "cpf_tk_k_" + strings.Repeat("a", MaxInputSize), "", ErrMalformedToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := TokenKeyID(tt.token)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("TokenKeyID(%q) error = %v, want %v", tt.token, err, tt.expectedErr)
+			}
+			if id != tt.expectedID {
+				t.Errorf("TokenKeyID(%q) = %q, want %q", tt.token, id, tt.expectedID)
+			}
+		})
+	}
+}
+
+// Test key validation and invalid documents
+func TestNewTokenizer_Errors(t *testing.T) {
+	short := TokenKey{ID: "short", Secret: []byte("too short")}
+	tests := []struct {
+		name     string
+		current  TokenKey
+		previous []TokenKey
+	}{
+		{"Short secret", short, nil},
+		{"Short previous secret", testKey2025, []TokenKey{short}},
+		{"Empty ID", TokenKey{Secret: testKey2024.Secret}, nil},
+		{"ID with separator", TokenKey{ID: "k_1", Secret: testKey2024.Secret}, nil},
+		{"Duplicate ID", testKey2024, []TokenKey{testKey2024}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTokenizer(tt.current, tt.previous...); !errors.Is(err, ErrInvalidTokenKey) {
+				t.Errorf("NewTokenizer() error = %v, want %v", err, ErrInvalidTokenKey)
+			}
+		})
+	}
+
+	tok, _ := NewTokenizer(testKey2024)
+	if _, err := tok.Token(CPF("71656686758")); !errors.Is(err, ErrCPFInvalidChecksum) {
+		t.Errorf("Token(invalid CPF) error = %v, want %v", err, ErrCPFInvalidChecksum)
+	}
+}
+
+// Test that the tokenizer keeps its own copy of the secret
+func TestNewTokenizer_CopiesSecret(t *testing.T) {
+	key := TokenKey{ID: "k", Secret: bytes.Repeat([]byte{1}, MinTokenKeySize)}
+	tok, _ := NewTokenizer(key)
+	before, _ := tok.Token(CPF("71656686759"))
+
+	key.Secret[0] = 2
+	after, _ := tok.Token(CPF("71656686759"))
+	if before != after {
+		t.Error("modifying the caller's secret changed the tokens")
+	}
+}
+
+// Benchmark token creation
+func BenchmarkTokenizer_Token(b *testing.B) {
+	tok, _ := NewTokenizer(testKey2024)
+	doc := CPF("71656686759")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = tok.Token(doc)
+	}
+}
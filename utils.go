@@ -12,15 +12,20 @@ var (
 	ErrInvalidCharacter = errors.New("document contains invalid character")
 
 	// CPF-specific errors
-	ErrCPFInvalidLength   = errors.New("CPF must have exactly 11 digits")
-	ErrCPFInvalidChecksum = errors.New("CPF checksum validation failed")
+	ErrCPFInvalidLength     = errors.New("CPF must have exactly 11 digits")
+	ErrCPFInvalidChecksum   = errors.New("CPF checksum validation failed")
+	ErrCPFInvalidBaseLength = errors.New("CPF base must have exactly 9 digits")
 
 	// CNPJ-specific errors
 	ErrCNPJInvalidLength       = errors.New("CNPJ must have exactly 14 characters")
 	ErrCNPJInvalidChecksum     = errors.New("CNPJ checksum validation failed")
+	ErrCNPJInvalidBaseLength   = errors.New("CNPJ base must have exactly 12 characters")
 	ErrCNPJInvalidAlphanumeric = errors.New("CNPJ alphanumeric format invalid: " +
 		"first 12 must be A-Z or 0-9, last 2 must be digits")
 
+	// Configuration errors
+	ErrInvalidOption = errors.New("invalid option")
+
 	// Security-related errors
 	ErrInputTooLarge = errors.New("input string too large: maximum 1000 characters allowed")
 )
@@ -100,56 +105,139 @@ func getCharacterValue(char byte) (int, error) {
 	return 0, fmt.Errorf("invalid character '%c' (ASCII %d): %w", char, char, ErrInvalidCharacter)
 }
 
+// byteString is satisfied by strings and byte slices, so the validation core can run
+// on either without converting (and allocating).
+type byteString interface {
+	~string | ~[]byte
+}
+
 func sumDigit(s string, table []int) (int, error) {
-	// Process up to the minimum length to handle mismatched inputs gracefully
-	length := len(s)
-	if len(table) < length {
-		length = len(table)
+	sum, invalid := weightedSum(s, table)
+	if invalid != -1 {
+		_, err := getCharacterValue(s[invalid])
+		return 0, fmt.Errorf("error processing character at position %d: %w", invalid, err)
 	}
+	return sum, nil
+}
+
+// weightedSum returns the Module 11 weighted sum of s, or the position of the first
+// character that has no value. Like sumDigit, it stops at the shorter of s and table.
+func weightedSum[T byteString](s T, table []int) (sum, invalid int) {
+	length := min(len(s), len(table))
 
-	sum := 0
 	for i := 0; i < length; i++ {
-		charValue, err := getCharacterValue(s[i])
-		if err != nil {
-			return 0, fmt.Errorf("error processing character at position %d: %w", i, err)
+		ch := s[i]
+		switch {
+		case ch >= '0' && ch <= '9':
+			sum += table[i] * int(ch-'0')
+		case ch >= 'A' && ch <= 'Z':
+			sum += table[i] * int(ch-48) // same values as getCharacterValue: A=17 ... Z=42
+		default:
+			return 0, i
 		}
-		sum += table[i] * charValue
 	}
-	return sum, nil
+	return sum, -1
 }
 
-func calculateModule11Digits(base string, firstTable, secondTable []int) (firstDigit, secondDigit int, err error) {
-	// Calculate first verification digit
-	sum1, err := sumDigit(base, firstTable)
-	if err != nil {
-		return 0, 0, fmt.Errorf("error calculating first digit: %w", err)
+// module11Digit turns a weighted sum into a check digit.
+func module11Digit(sum int) int {
+	if remainder := sum % 11; remainder >= 2 {
+		return 11 - remainder
 	}
-	remainder1 := sum1 % 11
-	digit1 := 0
-	if remainder1 >= 2 {
-		digit1 = 11 - remainder1
+	return 0
+}
+
+// module11Digits is the allocation-free core of calculateModule11Digits.
+// ok is false if base contains a character that has no value.
+func module11Digits[T byteString](base T, firstTable, secondTable []int) (firstDigit, secondDigit int, ok bool) {
+	sum1, invalid := weightedSum(base, firstTable)
+	if invalid != -1 {
+		return 0, 0, false
 	}
+	firstDigit = module11Digit(sum1)
 
-	// Calculate second verification digit
-	sum2, err := sumDigit(base, secondTable[:len(base)]) // Only use weights for base length
-	if err != nil {
-		return 0, 0, fmt.Errorf("error calculating second digit: %w", err)
+	sum2, invalid := weightedSum(base, secondTable[:len(base)]) // Only use weights for base length
+	if invalid != -1 {
+		return 0, 0, false
 	}
 	if len(secondTable) > len(base) {
 		// Add the contribution of the first digit with its corresponding weight
-		sum2 += digit1 * secondTable[len(base)]
+		sum2 += firstDigit * secondTable[len(base)]
+	}
+
+	return firstDigit, module11Digit(sum2), true
+}
+
+func calculateModule11Digits(base string, firstTable, secondTable []int) (firstDigit, secondDigit int, err error) {
+	if d1, d2, ok := module11Digits(base, firstTable, secondTable); ok {
+		return d1, d2, nil
+	}
+
+	// Slow path: report which character failed
+	if _, err := sumDigit(base, firstTable); err != nil {
+		return 0, 0, fmt.Errorf("error calculating first digit: %w", err)
+	}
+	_, err = sumDigit(base, secondTable[:len(base)])
+	return 0, 0, fmt.Errorf("error calculating second digit: %w", err)
+}
+
+// hasValidCheckDigits reports whether the last two characters of s are the Module 11
+// check digits of the rest.
+func hasValidCheckDigits[T byteString](s T, firstTable, secondTable []int) bool {
+	n := len(s) - 2
+	d1, d2, ok := module11Digits(s[:n], firstTable, secondTable)
+	return ok && s[n] == byte('0'+d1) && s[n+1] == byte('0'+d2)
+}
+
+// cleanInto writes the alphanumeric characters of s, uppercased, into dst and returns how many
+// there are in total, which may exceed len(dst). It is the allocation-free counterpart of cleanString.
+func cleanInto[T byteString](dst []byte, s T) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case ch >= '0' && ch <= '9', ch >= 'A' && ch <= 'Z':
+		case ch >= 'a' && ch <= 'z':
+			ch -= 'a' - 'A'
+		default:
+			continue
+		}
+		if n < len(dst) {
+			dst[n] = ch
+		}
+		n++
 	}
+	return n
+}
 
-	remainder2 := sum2 % 11
-	digit2 := 0
-	if remainder2 >= 2 {
-		digit2 = 11 - remainder2
+// checkDigitMismatch returns the position of the first of the last two characters of s
+// that differs from the expected check digits d1 and d2, or -1 if both match.
+func checkDigitMismatch(s string, d1, d2 int) int {
+	n := len(s) - 2
+	switch {
+	case s[n] != byte('0'+d1):
+		return n
+	case s[n+1] != byte('0'+d2):
+		return n + 1
 	}
+	return -1
+}
 
-	return digit1, digit2, nil
+// digitPair returns two check digits as a string.
+func digitPair(d1, d2 int) string {
+	return string([]byte{byte('0' + d1), byte('0' + d2)})
+}
+
+// checkDigits returns the two Module 11 check digits for base as a string.
+func checkDigits(base string, firstTable, secondTable []int) (string, error) {
+	d1, d2, err := calculateModule11Digits(base, firstTable, secondTable)
+	if err != nil {
+		return "", err
+	}
+	return digitPair(d1, d2), nil
 }
 
-func isSameCharacter(s string) bool {
+func isSameCharacter[T byteString](s T) bool {
 	if len(s) <= 1 {
 		return false
 	}
@@ -197,21 +285,47 @@ func filterToDigitsOnly(s string) string {
 	}, s)
 }
 
+// formatDocument lays s out in pattern. Each 'X' is replaced by the next character of s and
+// each '*' consumes one and writes '*' in its place, so the same call formats and masks.
+// Every other pattern byte is copied as-is.
 func formatDocument(s, pattern string) string {
-	var result strings.Builder
-	result.Grow(len(pattern))
+	return string(appendDocument(make([]byte, 0, len(pattern)), s, pattern))
+}
 
+// appendDocument works like formatDocument, appending the result to dst.
+func appendDocument(dst []byte, s, pattern string) []byte {
 	pos := 0
 	for i := 0; i < len(pattern); i++ {
-		if pattern[i] == 'X' {
+		switch pattern[i] {
+		case 'X':
+			if pos < len(s) {
+				dst = append(dst, s[pos])
+				pos++
+			}
+		case '*':
 			if pos < len(s) {
-				result.WriteByte(s[pos])
+				dst = append(dst, '*')
 				pos++
 			}
-		} else {
-			result.WriteByte(pattern[i])
+		default:
+			dst = append(dst, pattern[i])
 		}
 	}
 
-	return result.String()
+	return dst
+}
+
+// maskAll replaces every character of s with '*'.
+func maskAll(s string) string {
+	return strings.Repeat("*", len(s))
+}
+
+// hasLetter reports whether s contains an uppercase ASCII letter.
+func hasLetter(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 'A' && s[i] <= 'Z' {
+			return true
+		}
+	}
+	return false
 }
@@ -520,3 +520,84 @@ func BenchmarkRealWorldWorkloads(b *testing.B) {
 		}
 	})
 }
+
+// BenchmarkIsValid tests the allocation-free predicates
+func BenchmarkIsValid(b *testing.B) {
+	b.Run("Cpf_Clean", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = IsValidCpf(cpfClean)
+		}
+	})
+
+	b.Run("Cpf_Formatted", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = IsValidCpf(cpfFormatted)
+		}
+	})
+
+	b.Run("Cpf_Invalid", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = IsValidCpf(cpfInvalid)
+		}
+	})
+
+	b.Run("Cnpj_Numeric_Clean", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = IsValidCnpj(cnpjNumericClean)
+		}
+	})
+
+	b.Run("Cnpj_Alpha_Lowercase", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = IsValidCnpj(cnpjAlphaLowercase)
+		}
+	})
+
+	b.Run("Cnpj_Alpha_Invalid", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = IsValidCnpj(cnpjAlphaInvalid)
+		}
+	})
+}
+
+// BenchmarkBytesAPI tests parsing from and appending to byte slices
+func BenchmarkBytesAPI(b *testing.B) {
+	cpfBytes := []byte(cpfFormatted)
+	cnpjBytes := []byte(cnpjAlphaLowercase)
+	validCPF := CPF(cpfClean)
+	validCNPJ := CNPJ(cnpjAlphaClean)
+
+	b.Run("ParseCpfBytes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = ParseCpfBytes(cpfBytes)
+		}
+	})
+
+	b.Run("ParseCnpjBytes", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = ParseCnpjBytes(cnpjBytes)
+		}
+	})
+
+	b.Run("CPF_AppendFormatted", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			appendBuf = validCPF.AppendFormatted(appendBuf[:0])
+		}
+	})
+
+	b.Run("CNPJ_AppendFormatted", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			appendBuf = validCNPJ.AppendFormatted(appendBuf[:0])
+		}
+	})
+}
@@ -222,6 +222,10 @@ func TestFormatDocument(t *testing.T) {
 		{"Custom pattern 1", "12345", "XX-XX-X", "12-34-5"},
 		{"Custom pattern 2", "ABCDE", "X.X.X.X.X", "A.B.C.D.E"},
 		{"Mixed separators", "123456", "XX/XX-XX", "12/34-56"},
+
+		// Masking patterns
+		{"CPF masked", "71656686759", "***.XXX.XXX-**", "***.566.867-**"},
+		{"Masked short input", "123", "X**.XXX", "1**."},
 	}
 
 	for _, tt := range tests {
@@ -233,3 +237,73 @@ func TestFormatDocument(t *testing.T) {
 		})
 	}
 }
+
+// Test that the predicates agree with the constructors
+func TestIsValid_MatchesConstructors(t *testing.T) {
+	inputs := []string{
+		"", "123", "716.566.867-59", "71656686759", " 7!1@6#5$6%6^8&6*7(-5)9 ", "716.566.867-58",
+		"11111111111", "71656686A59", "7165668675A9", "71656686759AB", "716566867591234",
+		"22.796.729/0001-59", "22796729000159", "22796729000158", "00000000000000", "AAAAAAAAAAAAAA",
+		"12.ABC.345/01DE-35", "12.abc.345/01de-35", "12ABC34501DE99", "12ABC34501DEAB", "12ABC34501DE355",
+		"７１６.５６６.８６７-５９", "716.566.867-59\x00", strings.Repeat("1", MaxInputSize+1),
+	}
+	for i := 0; i < 50; i++ {
+		cpf, _ := GenerateCpf()
+		cnpj, _ := GenerateCnpj(WithAlphanumeric())
+		inputs = append(inputs, cpf.String(), cnpj.Raw(), strings.ToLower(cnpj.String()))
+	}
+
+	for _, input := range inputs {
+		_, cpfErr := NewCpf(input)
+		if got := IsValidCpf(input); got != (cpfErr == nil) {
+			t.Errorf("IsValidCpf(%q) = %v, NewCpf error = %v", input, got, cpfErr)
+		}
+
+		_, cnpjErr := NewCnpj(input)
+		if got := IsValidCnpj(input); got != (cnpjErr == nil) {
+			t.Errorf("IsValidCnpj(%q) = %v, NewCnpj error = %v", input, got, cnpjErr)
+		}
+	}
+}
+
+// appendBuf is reused by the Append benchmarks and allocation tests
+var appendBuf = make([]byte, 0, 64)
+
+// Test that validation does not allocate on the hot paths
+func TestValidation_ZeroAllocs(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func()
+	}{
+		{"NewCpf clean", func() { _, _ = NewCpf("71656686759") }},
+		{"NewCnpj numeric clean", func() { _, _ = NewCnpj("22796729000159") }},
+		{"NewCnpj alphanumeric clean", func() { _, _ = NewCnpj("12ABC34501DE35") }},
+		{"IsValidCpf formatted", func() { _ = IsValidCpf("716.566.867-59") }},
+		{"IsValidCpf invalid", func() { _ = IsValidCpf("716.566.867-58") }},
+		{"IsValidCnpj lowercase", func() { _ = IsValidCnpj("12.abc.345/01de-35") }},
+		{"IsValidCnpj invalid", func() { _ = IsValidCnpj("12ABC34501DE99") }},
+		{"AppendFormatted CPF", func() { appendBuf = CPF("71656686759").AppendFormatted(appendBuf[:0]) }},
+		{"AppendFormatted CNPJ", func() { appendBuf = CNPJ("12ABC34501DE35").AppendFormatted(appendBuf[:0]) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if allocs := testing.AllocsPerRun(100, tt.fn); allocs != 0 {
+				t.Errorf("%s allocated %v times per run, want 0", tt.name, allocs)
+			}
+		})
+	}
+}
+
+// Test that parsing bytes allocates only the returned document
+func TestParseBytes_Allocs(t *testing.T) {
+	cpf := []byte("716.566.867-59")
+	cnpj := []byte("12.abc.345/01de-35")
+
+	if allocs := testing.AllocsPerRun(100, func() { _, _ = ParseCpfBytes(cpf) }); allocs > 1 {
+		t.Errorf("ParseCpfBytes allocated %v times per run, want at most 1", allocs)
+	}
+	if allocs := testing.AllocsPerRun(100, func() { _, _ = ParseCnpjBytes(cnpj) }); allocs > 1 {
+		t.Errorf("ParseCnpjBytes allocated %v times per run, want at most 1", allocs)
+	}
+}
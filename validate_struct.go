@@ -0,0 +1,415 @@
+package cpfcnpj
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Errors returned by ValidateStruct for programming mistakes, as opposed to invalid documents
+var (
+	ErrInvalidStructTag = errors.New("invalid cpfcnpj struct tag")
+	ErrNotStruct        = errors.New("ValidateStruct requires a struct or a pointer to a struct")
+)
+
+// structTag is the struct tag key read by ValidateStruct.
+const structTag = "cpfcnpj"
+
+// maxElemDepth bounds the element types followed when deciding whether a container can hold
+// structs, so recursive types such as `type L []L` terminate.
+const maxElemDepth = 16
+
+// FieldError reports an invalid document in a struct field.
+type FieldError struct {
+	// Path locates the field, e.g. "Billing.CNPJ", "Payees[2].Document" or "Owners[acme]".
+	Path string
+	// Err is the validation error, wrapping the same sentinels as NewCpf, NewCnpj and Parse.
+	Err error
+}
+
+// Error returns the path followed by the validation error.
+func (e *FieldError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+// Unwrap returns the validation error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FieldErrors lists every invalid field found by ValidateStruct, in field order.
+// errors.Is and errors.As see each FieldError, and through it each validation error.
+type FieldErrors []*FieldError
+
+// Error joins the field errors with "; ".
+func (e FieldErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the field errors.
+func (e FieldErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, fe := range e {
+		errs[i] = fe
+	}
+	return errs
+}
+
+// ValidateStruct validates the document fields of v, which must be a struct or a pointer to one.
+// Fields are selected with a cpfcnpj struct tag:
+//
+//	type Payee struct {
+//	    CPF      string            `cpfcnpj:"cpf"`
+//	    Company  *string           `cpfcnpj:"cnpj,normalize=formatted"`
+//	    Document string            `cpfcnpj:"any,normalize=raw,omitempty"`
+//	    Aliases  map[string]string `cpfcnpj:"any"`
+//	}
+//
+// The first option selects the validator: cpf uses NewCpf, cnpj uses NewCnpj and any detects
+// the type with Parse. normalize=raw or normalize=formatted rewrites valid values in place,
+// which requires v to be a pointer. omitempty skips empty strings; nil pointers are always
+// skipped. Tagged fields may be strings, or pointers, slices, arrays or maps of strings.
+// Untagged struct, pointer, slice, array, map and interface fields are walked recursively.
+//
+// Invalid documents are reported together as FieldErrors. Invalid tags and arguments return
+// an error wrapping ErrInvalidStructTag or ErrNotStruct instead.
+func ValidateStruct(v any) error {
+	rv := reflect.ValueOf(v)
+	w := structWalker{visited: map[visit]bool{}}
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		w.rewrite = true
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("%w, got %T", ErrNotStruct, v)
+	}
+
+	if err := w.walk(rv, ""); err != nil {
+		return err
+	}
+	if len(w.errs) > 0 {
+		return w.errs
+	}
+	return nil
+}
+
+// fieldTag is a parsed cpfcnpj struct tag.
+type fieldTag struct {
+	kind      Kind
+	normalize Format
+	omitEmpty bool
+}
+
+// parseFieldTag parses a tag such as "any,normalize=raw,omitempty".
+func parseFieldTag(tag string) (fieldTag, error) {
+	name, opts, _ := strings.Cut(tag, ",")
+
+	var ft fieldTag
+	switch name {
+	case "cpf":
+		ft.kind = KindCPF
+	case "cnpj":
+		ft.kind = KindCNPJNumeric
+	case "any":
+		ft.kind = KindUnknown
+	default:
+		return ft, fmt.Errorf("%w %q: kind must be cpf, cnpj or any", ErrInvalidStructTag, tag)
+	}
+
+	for opt := range strings.SplitSeq(opts, ",") {
+		switch opt {
+		case "":
+		case "omitempty":
+			ft.omitEmpty = true
+		case "normalize=raw":
+			ft.normalize = FormatRaw
+		case "normalize=formatted":
+			ft.normalize = FormatFormatted
+		default:
+			return ft, fmt.Errorf("%w %q: unknown option %q", ErrInvalidStructTag, tag, opt)
+		}
+	}
+	return ft, nil
+}
+
+// structField describes a field ValidateStruct looks at.
+type structField struct {
+	index    int
+	name     string
+	tag      *fieldTag // nil for fields that are walked
+	embedded bool      // embedded fields are walked without adding their name to the path
+}
+
+// structFields is the cached result of fieldsOf.
+type structFields struct {
+	fields []structField
+	err    error
+}
+
+// fieldsCache maps a reflect.Type to its structFields.
+var fieldsCache sync.Map
+
+// fieldsOf returns the fields of struct type t that are validated or walked.
+func fieldsOf(t reflect.Type) ([]structField, error) {
+	if cached, ok := fieldsCache.Load(t); ok {
+		sf := cached.(structFields)
+		return sf.fields, sf.err
+	}
+
+	var sf structFields
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag, tagged := f.Tag.Lookup(structTag)
+		if tag == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+
+		if !tagged {
+			if mayContainStructs(f.Type) {
+				sf.fields = append(sf.fields, structField{index: i, name: f.Name, embedded: f.Anonymous})
+			}
+			continue
+		}
+
+		ft, err := parseFieldTag(tag)
+		if err == nil && !isStringContainer(f.Type) {
+			err = fmt.Errorf("%w: type %s holds no strings", ErrInvalidStructTag, f.Type)
+		}
+		if err != nil {
+			sf = structFields{err: fmt.Errorf("%s.%s: %w", t, f.Name, err)}
+			break
+		}
+		sf.fields = append(sf.fields, structField{index: i, name: f.Name, tag: &ft})
+	}
+
+	fieldsCache.Store(t, sf)
+	return sf.fields, sf.err
+}
+
+// mayContainStructs reports whether values of type t can hold struct fields to walk.
+func mayContainStructs(t reflect.Type) bool {
+	for range maxElemDepth {
+		//exhaustive:ignore no other kind can hold a struct
+		switch t.Kind() {
+		case reflect.Struct, reflect.Interface:
+			return true
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isStringContainer reports whether t is a string, or a pointer, slice, array or map of them.
+func isStringContainer(t reflect.Type) bool {
+	for range maxElemDepth {
+		//exhaustive:ignore no other kind can hold a string
+		switch t.Kind() {
+		case reflect.String:
+			return true
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// visit identifies a pointer already walked; the type is needed because a struct
+// and its first field share an address.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// structWalker holds the state of one ValidateStruct call.
+type structWalker struct {
+	rewrite bool // whether valid values may be normalized in place
+	changed bool // whether the last walk rewrote a value, so map entries must be stored back
+	visited map[visit]bool
+	errs    FieldErrors
+}
+
+// walk validates the tagged fields reachable from v.
+func (w *structWalker) walk(v reflect.Value, path string) error {
+	//exhaustive:ignore values of any other kind cannot hold struct fields
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return w.walkElem(v, path)
+	case reflect.Struct:
+		return w.walkStruct(v, path)
+	case reflect.Slice, reflect.Array:
+		if mayContainStructs(v.Type().Elem()) {
+			return w.walkIndexes(v, path)
+		}
+	case reflect.Map:
+		if mayContainStructs(v.Type().Elem()) {
+			for elem, elemPath := range w.mapValues(v, path) {
+				if err := w.walk(elem, elemPath); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// walkElem walks the value pointer or interface v holds, skipping pointers already walked.
+func (w *structWalker) walkElem(v reflect.Value, path string) error {
+	if v.IsNil() {
+		return nil
+	}
+	if v.Kind() == reflect.Pointer {
+		key := visit{v.Pointer(), v.Type()}
+		if w.visited[key] {
+			return nil
+		}
+		w.visited[key] = true
+	}
+	// Strings held directly in an interface are not addressable, so check leaves them as they are
+	return w.walk(v.Elem(), path)
+}
+
+// walkStruct checks the tagged fields of struct v and walks the others.
+func (w *structWalker) walkStruct(v reflect.Value, path string) error {
+	fields, err := fieldsOf(v.Type())
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		fv := v.Field(f.index)
+		fpath := joinPath(path, f.name)
+		if f.embedded {
+			fpath = path
+		}
+
+		if f.tag != nil {
+			w.check(fv, fpath, *f.tag)
+		} else if err := w.walk(fv, fpath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkIndexes walks every element of slice or array v.
+func (w *structWalker) walkIndexes(v reflect.Value, path string) error {
+	for i := range v.Len() {
+		if err := w.walk(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// check validates the tagged value v, normalizing it when the tag asks for it.
+func (w *structWalker) check(v reflect.Value, path string, tag fieldTag) {
+	//exhaustive:ignore fieldsOf only accepts tags on strings and containers of strings
+	switch v.Kind() {
+	case reflect.String:
+		w.checkString(v, path, tag)
+	case reflect.Pointer:
+		if !v.IsNil() {
+			w.check(v.Elem(), path, tag)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range v.Len() {
+			w.check(v.Index(i), fmt.Sprintf("%s[%d]", path, i), tag)
+		}
+	case reflect.Map:
+		for elem, elemPath := range w.mapValues(v, path) {
+			w.check(elem, elemPath, tag)
+		}
+	}
+}
+
+// checkString validates the string v, rewriting it in the tag's format when it is valid.
+func (w *structWalker) checkString(v reflect.Value, path string, tag fieldTag) {
+	s := v.String()
+	if s == "" && tag.omitEmpty {
+		return
+	}
+
+	doc, err := ParseKind(tag.kind, s)
+	if err != nil {
+		w.errs = append(w.errs, &FieldError{Path: path, Err: err})
+		return
+	}
+
+	normalized := s
+	switch tag.normalize {
+	case FormatRaw:
+		normalized = doc.Raw()
+	case FormatFormatted:
+		normalized = doc.String()
+	case FormatUnchanged:
+	}
+	if normalized != s && w.rewrite && v.CanSet() {
+		v.SetString(normalized)
+		w.changed = true
+	}
+}
+
+// mapValues yields an addressable copy of every value of map m, in key order, with its path,
+// and stores the copies that the loop body rewrote back into m.
+func (w *structWalker) mapValues(m reflect.Value, path string) iter.Seq2[reflect.Value, string] {
+	return func(yield func(reflect.Value, string) bool) {
+		keys := m.MapKeys()
+		slices.SortFunc(keys, compareMapKeys)
+
+		changed := w.changed
+		defer func() { w.changed = changed }()
+		for _, key := range keys {
+			elem := reflect.New(m.Type().Elem()).Elem()
+			elem.Set(m.MapIndex(key))
+
+			w.changed = false
+			more := yield(elem, fmt.Sprintf("%s[%v]", path, key))
+			if w.changed {
+				m.SetMapIndex(key, elem)
+				changed = true
+			}
+			if !more {
+				return
+			}
+		}
+	}
+}
+
+// compareMapKeys orders map keys the way slices.Sorted does in code generated by cpfcnpj-gen:
+// numbers and strings by value, and any other key type by its fmt.Sprint form.
+func compareMapKeys(a, b reflect.Value) int {
+	//exhaustive:ignore every other kind is ordered by its fmt.Sprint form
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	default:
+		return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+}
+
+// joinPath appends a field name to a path.
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,220 @@
+package cpfcnpj
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+type structAddress struct {
+	Owner string `cpfcnpj:"cpf,omitempty"`
+}
+
+type structCompany struct {
+	CNPJ    string `cpfcnpj:"cnpj,normalize=formatted"`
+	Address structAddress
+}
+
+type structEmbedded struct {
+	Registration string `cpfcnpj:"any,normalize=raw"`
+}
+
+type structRequest struct {
+	structEmbedded
+	CPF       string            `cpfcnpj:"cpf,normalize=raw"`
+	Optional  *string           `cpfcnpj:"any,normalize=formatted"`
+	Payees    []string          `cpfcnpj:"any,normalize=raw"`
+	Aliases   map[string]string `cpfcnpj:"any,normalize=formatted"`
+	Companies []structCompany
+	ByBranch  map[string]*structCompany
+	Extra     any
+	Ignored   string `cpfcnpj:"-"`
+	Untagged  string
+	unexport  string `cpfcnpj:"cpf"`
+}
+
+// validStructRequest returns a structRequest whose documents are all valid but unnormalized
+func validStructRequest() *structRequest {
+	optional := "22796729000159"
+	return &structRequest{
+		structEmbedded: structEmbedded{Registration: "12.ABC.345/01DE-35"},
+		CPF:            "716.566.867-59",
+		Optional:       &optional,
+		Payees:         []string{"716.566.867-59", "22.796.729/0001-59"},
+		Aliases:        map[string]string{"b": "71656686759", "a": "12abc34501de35"},
+		Companies:      []structCompany{{CNPJ: "22796729000159"}},
+		ByBranch:       map[string]*structCompany{"hq": {CNPJ: "22796729000159"}},
+		Extra:          &structAddress{Owner: "716.566.867-59"},
+		Ignored:        "not a document",
+		Untagged:       "not a document",
+		unexport:       "not a document",
+	}
+}
+
+// Test that valid structs pass and are normalized in place
+func TestValidateStruct_Normalize(t *testing.T) {
+	req := validStructRequest()
+	if err := ValidateStruct(req); err != nil {
+		t.Fatalf("ValidateStruct() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"Embedded", req.Registration, "12ABC34501DE35"},
+		{"Field", req.CPF, "71656686759"},
+		{"Pointer", *req.Optional, "22.796.729/0001-59"},
+		{"Slice element 0", req.Payees[0], "71656686759"},
+		{"Slice element 1", req.Payees[1], "22796729000159"},
+		{"Map value a", req.Aliases["a"], "12.ABC.345/01DE-35"},
+		{"Map value b", req.Aliases["b"], "716.566.867-59"},
+		{"Nested struct in slice", req.Companies[0].CNPJ, "22.796.729/0001-59"},
+		{"Nested pointer in map", req.ByBranch["hq"].CNPJ, "22.796.729/0001-59"},
+		{"Untagged", req.Untagged, "not a document"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("got %q, want %q", tt.got, tt.expected)
+			}
+		})
+	}
+}
+
+// Test that a struct passed by value is validated but left unchanged
+func TestValidateStruct_Value(t *testing.T) {
+	req := validStructRequest()
+	if err := ValidateStruct(*req); err != nil {
+		t.Fatalf("ValidateStruct() error = %v", err)
+	}
+	if req.CPF != "716.566.867-59" || req.Aliases["a"] != "12abc34501de35" {
+		t.Errorf("struct value was rewritten: CPF %q, Aliases %v", req.CPF, req.Aliases)
+	}
+}
+
+// Test that every invalid field is reported with its path and sentinel
+func TestValidateStruct_FieldErrors(t *testing.T) {
+	req := validStructRequest()
+	req.Registration = "12.ABC.345/01DE-99"
+	req.CPF = ""
+	req.Payees[1] = "123"
+	req.Aliases["c"] = "71656686758"
+	req.Companies = append(req.Companies, structCompany{CNPJ: "22796729000159",
+		Address: structAddress{Owner: "71656686758"}})
+	req.ByBranch["branch"] = &structCompany{CNPJ: "22796729000158"}
+	req.Extra = map[string]any{"x": structAddress{Owner: "11111111111"}}
+
+	err := ValidateStruct(req)
+
+	var fieldErrs FieldErrors
+	if !errors.As(err, &fieldErrs) {
+		t.Fatalf("ValidateStruct() error = %v, want FieldErrors", err)
+	}
+
+	expected := []struct {
+		path     string
+		sentinel error
+	}{
+		{"Registration", ErrCNPJInvalidChecksum},
+		{"CPF", ErrCPFInvalidLength},
+		{"Payees[1]", ErrUnknownDocument},
+		{"Aliases[c]", ErrCPFInvalidChecksum},
+		{"Companies[1].Address.Owner", ErrCPFInvalidChecksum},
+		{"ByBranch[branch].CNPJ", ErrCNPJInvalidChecksum},
+		{"Extra[x].Owner", ErrAllSameDigits},
+	}
+	paths := make([]string, len(fieldErrs))
+	for i, fe := range fieldErrs {
+		paths[i] = fe.Path
+	}
+	if len(fieldErrs) != len(expected) {
+		t.Fatalf("got %d field errors %v, want %d", len(fieldErrs), paths, len(expected))
+	}
+
+	for i, want := range expected {
+		if fieldErrs[i].Path != want.path || !errors.Is(fieldErrs[i], want.sentinel) {
+			t.Errorf("field error %d = %v, want path %q wrapping %v", i, fieldErrs[i], want.path, want.sentinel)
+		}
+		if !errors.Is(err, want.sentinel) {
+			t.Errorf("errors.Is(err, %v) = false", want.sentinel)
+		}
+	}
+	if CodeOf(err) != CodeInvalidChecksum {
+		t.Errorf("CodeOf(err) = %q, want %q", CodeOf(err), CodeInvalidChecksum)
+	}
+
+	// Invalid values are never rewritten
+	if req.Registration != "12.ABC.345/01DE-99" || !slices.Equal(req.Payees, []string{"71656686759", "123"}) {
+		t.Errorf("invalid values were rewritten: %q, %v", req.Registration, req.Payees)
+	}
+}
+
+// Test the errors for invalid tags and arguments
+func TestValidateStruct_UsageErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       any
+		expectedErr error
+	}{
+		{"Unknown kind", &struct {
+			Doc string `cpfcnpj:"rg"`
+		}{}, ErrInvalidStructTag},
+		{"Unknown option", &struct {
+			Doc string `cpfcnpj:"cpf,normalize=upper"`
+		}{}, ErrInvalidStructTag},
+		{"Tag on a non-string field", &struct {
+			Doc int `cpfcnpj:"cpf"`
+		}{}, ErrInvalidStructTag},
+		{"Tag in a nested struct", &struct {
+			Inner []struct {
+				Doc []byte `cpfcnpj:"cnpj"`
+			}
+		}{Inner: make([]struct {
+			Doc []byte `cpfcnpj:"cnpj"`
+		}, 1)}, ErrInvalidStructTag},
+		{"Nil", nil, ErrNotStruct},
+		{"Nil pointer", (*structRequest)(nil), ErrNotStruct},
+		{"String", "716.566.867-59", ErrNotStruct},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStruct(tt.input)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("ValidateStruct() error = %v, want %v", err, tt.expectedErr)
+			}
+			var fieldErrs FieldErrors
+			if errors.As(err, &fieldErrs) {
+				t.Errorf("usage error reported as FieldErrors: %v", err)
+			}
+		})
+	}
+}
+
+// Test that cyclic pointers are walked once
+func TestValidateStruct_Cycle(t *testing.T) {
+	type node struct {
+		CPF  string `cpfcnpj:"cpf"`
+		Next *node
+	}
+	a := &node{CPF: "71656686759"}
+	b := &node{CPF: "71656686758", Next: a}
+	a.Next = b
+
+	var fieldErrs FieldErrors
+	if err := ValidateStruct(a); !errors.As(err, &fieldErrs) || len(fieldErrs) != 1 || fieldErrs[0].Path != "Next.CPF" {
+		t.Errorf("ValidateStruct() error = %v, want one error at Next.CPF", err)
+	}
+}
+
+// Benchmark validating a struct with nested documents
+func BenchmarkValidateStruct(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if err := ValidateStruct(validStructRequest()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,328 @@
+package cpfcnpj
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Errors returned by a Validator for documents its policy rejects
+var (
+	ErrInvalidFormat    = errors.New("document must be clean or in the official format")
+	ErrLowercase        = errors.New("lowercase letters are not allowed")
+	ErrAlphanumericCNPJ = errors.New("alphanumeric CNPJ is not allowed")
+	ErrDenied           = errors.New("document is on the denylist")
+)
+
+// Layouts accepted by WithStrictFormat; 'A' stands for any letter or digit.
+var (
+	strictCPFLayouts  = []string{"AAAAAAAAAAA", "AAA.AAA.AAA-AA"}
+	strictCNPJLayouts = []string{"AAAAAAAAAAAAAA", "AA.AAA.AAA/AAAA-AA"}
+)
+
+// ValidatorOption configures a Validator.
+type ValidatorOption func(*Validator)
+
+// WithMaxInputSize lowers the longest input accepted, in bytes, from MaxInputSize to n.
+func WithMaxInputSize(n int) ValidatorOption {
+	return func(v *Validator) {
+		v.maxInputSize = n
+	}
+}
+
+// WithStrictFormat accepts only clean documents ("71656686759", "12ABC34501DE35") and
+// documents in the official layout ("716.566.867-59", "12.ABC.345/01DE-35"). Anything else,
+// such as spaces or misplaced separators, fails with ErrInvalidFormat.
+func WithStrictFormat() ValidatorOption {
+	return func(v *Validator) {
+		v.strictFormat = true
+	}
+}
+
+// WithAlphanumericCNPJ sets whether CNPJ Alfanumérico is accepted (default true).
+// When false, a CNPJ with letters in its first 12 characters fails with ErrAlphanumericCNPJ.
+func WithAlphanumericCNPJ(allow bool) ValidatorOption {
+	return func(v *Validator) {
+		v.rejectAlphanumeric = !allow
+	}
+}
+
+// WithLowercase sets whether lowercase letters are accepted and converted to uppercase
+// (default true). When false, input containing them fails with ErrLowercase.
+func WithLowercase(allow bool) ValidatorOption {
+	return func(v *Validator) {
+		v.rejectLowercase = !allow
+	}
+}
+
+// WithSameDigits sets whether documents whose characters are all the same, such as
+// "000.000.000-00", are accepted when their check digits are valid (default false).
+func WithSameDigits(allow bool) ValidatorOption {
+	return func(v *Validator) {
+		v.allowSameDigits = allow
+	}
+}
+
+// WithDenylist rejects the given documents with ErrDenied even though they are valid.
+// Entries may be formatted; they are cleaned with Clean. The option may be repeated.
+func WithDenylist(docs ...string) ValidatorOption {
+	return func(v *Validator) {
+		if v.denylist == nil {
+			v.denylist = make(map[string]struct{}, len(docs))
+		}
+		for _, doc := range docs {
+			v.denylist[Clean(doc)] = struct{}{}
+		}
+	}
+}
+
+// Validator validates documents under a configurable policy. It is immutable once built
+// and safe for concurrent use.
+//
+// The zero value, like NewValidator with no options, accepts exactly what NewCpf, NewCnpj
+// and Parse accept.
+type Validator struct {
+	maxInputSize       int // 0 means MaxInputSize
+	strictFormat       bool
+	rejectAlphanumeric bool
+	rejectLowercase    bool
+	allowSameDigits    bool
+	denylist           map[string]struct{}
+}
+
+// NewValidator returns a Validator configured by opts:
+//
+//	v, err := cpfcnpj.NewValidator(
+//	    cpfcnpj.WithStrictFormat(),
+//	    cpfcnpj.WithAlphanumericCNPJ(false),
+//	    cpfcnpj.WithDenylist("000.000.001-91"),
+//	)
+//
+// It returns an error wrapping ErrInvalidOption if an option is out of range.
+func NewValidator(opts ...ValidatorOption) (*Validator, error) {
+	v := &Validator{maxInputSize: MaxInputSize}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	if v.maxInputSize < 1 || v.maxInputSize > MaxInputSize {
+		return nil, fmt.Errorf("max input size must be between 1 and %d, got %d: %w", MaxInputSize,
+			v.maxInputSize, ErrInvalidOption)
+	}
+
+	return v, nil
+}
+
+// ValidateCpf validates s as a CPF under the Validator's policy.
+func (v *Validator) ValidateCpf(s string) (CPF, error) {
+	if err := v.checkInput(s, KindCPF); err != nil {
+		return "", err
+	}
+	return v.cpf(s)
+}
+
+// ValidateCnpj validates s as a CNPJ under the Validator's policy.
+func (v *Validator) ValidateCnpj(s string) (CNPJ, error) {
+	if err := v.checkInput(s, KindCNPJNumeric); err != nil {
+		return "", err
+	}
+	return v.cnpj(s)
+}
+
+// Parse detects whether s is a CPF or a CNPJ like the package-level Parse, and validates it
+// under the Validator's policy.
+func (v *Validator) Parse(s string) (Document, error) {
+	if err := v.checkInput(s, KindUnknown); err != nil {
+		return nil, err
+	}
+
+	cleaned := Clean(s)
+
+	switch len(cleaned) {
+	case CPFLength:
+		cpf, err := v.cpf(cleaned)
+		if err != nil {
+			return nil, err
+		}
+		return cpf, nil
+	case CNPJLength:
+		cnpj, err := v.cnpj(cleaned)
+		if err != nil {
+			return nil, err
+		}
+		return cnpj, nil
+	default:
+		return Parse(s)
+	}
+}
+
+// checkInput applies the policies that look at the raw input: size, layout and case.
+func (v *Validator) checkInput(s string, kind Kind) error {
+	label := kindLabel(kind)
+
+	maxSize := v.maxInputSize
+	if maxSize == 0 {
+		maxSize = MaxInputSize
+	}
+	if len(s) > maxSize {
+		return newValidationError(CodeInputTooLarge, kind, ErrInputTooLarge,
+			"%s input has %d characters, maximum is %d", label, len(s), maxSize)
+	}
+
+	if v.strictFormat && !matchesStrictLayout(s, kind) {
+		return newValidationError(CodeInvalidFormat, kind, ErrInvalidFormat,
+			"%s input has unexpected formatting characters", label)
+	}
+
+	if v.rejectLowercase {
+		// Position counts the letters and digits before the lowercase letter, so it points
+		// into the cleaned input like every other ValidationError.
+		pos := 0
+		for i := 0; i < len(s); i++ {
+			ch := s[i]
+			if ch >= 'a' && ch <= 'z' {
+				verr := newValidationError(CodeInvalidCharset, kind, ErrLowercase,
+					"%s input has lowercase %q at position %d", label, ch, pos)
+				verr.Position = pos
+				verr.Char = ch
+				return verr
+			}
+			if isAlphanumericByte(ch) {
+				pos++
+			}
+		}
+	}
+
+	return nil
+}
+
+// cpf validates s with NewCpf and applies the same-digit and denylist policies.
+func (v *Validator) cpf(s string) (CPF, error) {
+	cpf, err := NewCpf(s)
+	if v.allowSameDigits && errors.Is(err, ErrAllSameDigits) {
+		cpf = CPF(Clean(s))
+		err = sameCharacterChecksum(KindCPF, string(cpf))
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := v.checkDenylist(string(cpf), KindCPF); err != nil {
+		return "", err
+	}
+	return cpf, nil
+}
+
+// cnpj validates s with NewCnpj and applies the alphanumeric, same-digit and denylist policies.
+func (v *Validator) cnpj(s string) (CNPJ, error) {
+	if v.rejectAlphanumeric {
+		cleaned := Clean(s)
+		if len(cleaned) == CNPJLength {
+			if pos := strings.IndexFunc(cleaned[:CNPJBaseLength], isUpperLetter); pos != -1 {
+				return "", newCharsetError(KindCNPJAlphanumeric, cleaned, pos, ErrAlphanumericCNPJ,
+					"CNPJ has letter %q at position %d", cleaned[pos], pos)
+			}
+		}
+	}
+
+	cnpj, err := NewCnpj(s)
+	if v.allowSameDigits && errors.Is(err, ErrAllSameDigits) {
+		cnpj = CNPJ(Clean(s))
+		err = sameCharacterChecksum(cnpj.Kind(), string(cnpj))
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if err := v.checkDenylist(string(cnpj), cnpj.Kind()); err != nil {
+		return "", err
+	}
+	return cnpj, nil
+}
+
+// checkDenylist rejects a valid, cleaned document found in the denylist.
+func (v *Validator) checkDenylist(raw string, kind Kind) error {
+	if _, denied := v.denylist[raw]; denied {
+		return newValidationError(CodeDenied, kind, ErrDenied, "%s rejected", kindLabel(kind))
+	}
+	return nil
+}
+
+// sameCharacterChecksum validates the check digits of a cleaned document whose characters
+// are all the same, which NewCpf and NewCnpj reject before reaching the checksum.
+func sameCharacterChecksum(kind Kind, cleaned string) error {
+	baseLength, first, second, sentinel := CPFBaseLength, cpfFirstDigitTable, cpfSecondDigitTable,
+		ErrCPFInvalidChecksum
+	if kind.IsCNPJ() {
+		baseLength, first, second, sentinel = CNPJBaseLength, cnpjFirstDigitTable, cnpjSecondDigitTable,
+			ErrCNPJInvalidChecksum
+	}
+
+	d1, d2, err := calculateModule11Digits(cleaned[:baseLength], first, second)
+	if err != nil {
+		return fmt.Errorf("error calculating %s check digits: %w", kindLabel(kind), err)
+	}
+
+	if pos := checkDigitMismatch(cleaned, d1, d2); pos != -1 {
+		verr := newChecksumError(kind, sentinel, digitPair(d1, d2), cleaned[baseLength:],
+			"%s check digits are invalid", kindLabel(kind))
+		verr.Position = pos
+		verr.Char = cleaned[pos]
+		return verr
+	}
+	return nil
+}
+
+// matchesStrictLayout reports whether s is clean or in the official layout for kind.
+// KindUnknown accepts the layouts of both documents.
+func matchesStrictLayout(s string, kind Kind) bool {
+	var layouts []string
+	if kind != KindCPF {
+		layouts = append(layouts, strictCNPJLayouts...)
+	}
+	if !kind.IsCNPJ() {
+		layouts = append(layouts, strictCPFLayouts...)
+	}
+
+	for _, layout := range layouts {
+		if matchesLayout(s, layout) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesLayout reports whether s fits layout, where 'A' matches any ASCII letter or digit
+// and every other byte matches itself.
+func matchesLayout(s, layout string) bool {
+	if len(s) != len(layout) {
+		return false
+	}
+	for i := 0; i < len(layout); i++ {
+		if layout[i] == 'A' {
+			if !isAlphanumericByte(s[i]) {
+				return false
+			}
+		} else if s[i] != layout[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// isUpperLetter reports whether r is an ASCII uppercase letter.
+func isUpperLetter(r rune) bool {
+	return r >= 'A' && r <= 'Z'
+}
+
+// kindLabel names the document kind in error messages.
+func kindLabel(kind Kind) string {
+	switch {
+	case kind == KindCPF:
+		return "CPF"
+	case kind.IsCNPJ():
+		return "CNPJ"
+	default:
+		return "document"
+	}
+}
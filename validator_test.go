@@ -0,0 +1,189 @@
+package cpfcnpj
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// Test that each policy accepts and rejects the expected inputs
+func TestValidator_Parse(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []ValidatorOption
+		input        string
+		expectedRaw  string
+		expectedErr  error
+		expectedCode ErrorCode
+	}{
+		// Defaults match the package-level Parse
+		{name: "Default CPF", input: "716.566.867-59", expectedRaw: "71656686759"},
+		{name: "Default lowercase CNPJ", input: "12.abc.345/01de-35", expectedRaw: "12ABC34501DE35"},
+		{name: "Default loose formatting", input: " 716 566 867 59 ", expectedRaw: "71656686759"},
+		{name: "Default same digits", input: "111.111.111-11", expectedErr: ErrAllSameDigits,
+			expectedCode: CodeSameDigits},
+		{name: "Default checksum", input: "716.566.867-58", expectedErr: ErrCPFInvalidChecksum,
+			expectedCode: CodeInvalidChecksum},
+		{name: "Default unknown length", input: "123", expectedErr: ErrUnknownDocument,
+			expectedCode: CodeInvalidLength},
+
+		// WithMaxInputSize
+		{name: "Max size fits", opts: []ValidatorOption{WithMaxInputSize(14)}, input: "716.566.867-59",
+			expectedRaw: "71656686759"},
+		{name: "Max size exceeded", opts: []ValidatorOption{WithMaxInputSize(14)}, input: "12.ABC.345/01DE-35",
+			expectedErr: ErrInputTooLarge, expectedCode: CodeInputTooLarge},
+
+		// WithStrictFormat
+		{name: "Strict clean CPF", opts: []ValidatorOption{WithStrictFormat()}, input: "71656686759",
+			expectedRaw: "71656686759"},
+		{name: "Strict formatted CNPJ", opts: []ValidatorOption{WithStrictFormat()}, input: "12.ABC.345/01DE-35",
+			expectedRaw: "12ABC34501DE35"},
+		{name: "Strict spaces", opts: []ValidatorOption{WithStrictFormat()}, input: " 716.566.867-59",
+			expectedErr: ErrInvalidFormat, expectedCode: CodeInvalidFormat},
+		{name: "Strict misplaced separator", opts: []ValidatorOption{WithStrictFormat()}, input: "7165.66.867-59",
+			expectedErr: ErrInvalidFormat, expectedCode: CodeInvalidFormat},
+		{name: "Strict partial formatting", opts: []ValidatorOption{WithStrictFormat()}, input: "716566867-59",
+			expectedErr: ErrInvalidFormat, expectedCode: CodeInvalidFormat},
+
+		// WithAlphanumericCNPJ
+		{name: "Numeric only accepts numeric", opts: []ValidatorOption{WithAlphanumericCNPJ(false)},
+			input: "22.796.729/0001-59", expectedRaw: "22796729000159"},
+		{name: "Numeric only rejects letters", opts: []ValidatorOption{WithAlphanumericCNPJ(false)},
+			input: "12.ABC.345/01DE-35", expectedErr: ErrAlphanumericCNPJ, expectedCode: CodeInvalidCharset},
+
+		// WithLowercase
+		{name: "Uppercase only accepts uppercase", opts: []ValidatorOption{WithLowercase(false)},
+			input: "12.ABC.345/01DE-35", expectedRaw: "12ABC34501DE35"},
+		{name: "Uppercase only rejects lowercase", opts: []ValidatorOption{WithLowercase(false)},
+			input: "12.ABC.345/01dE-35", expectedErr: ErrLowercase, expectedCode: CodeInvalidCharset},
+
+		// WithSameDigits
+		{name: "Same digits CPF", opts: []ValidatorOption{WithSameDigits(true)}, input: "000.000.000-00",
+			expectedRaw: "00000000000"},
+		{name: "Same digits CNPJ", opts: []ValidatorOption{WithSameDigits(true)}, input: "00.000.000/0000-00",
+			expectedRaw: "00000000000000"},
+		{name: "Same digits bad checksum", opts: []ValidatorOption{WithSameDigits(true)},
+			input: "11.111.111/1111-11", expectedErr: ErrCNPJInvalidChecksum, expectedCode: CodeInvalidChecksum},
+		{name: "Same digits still validates length", opts: []ValidatorOption{WithSameDigits(true)},
+			input: "1111", expectedErr: ErrUnknownDocument, expectedCode: CodeInvalidLength},
+
+		// WithDenylist
+		{name: "Denied CPF", opts: []ValidatorOption{WithDenylist("000.000.001-91")}, input: "00000000191",
+			expectedErr: ErrDenied, expectedCode: CodeDenied},
+		{name: "Denied CNPJ", opts: []ValidatorOption{WithDenylist("71656686759"), WithDenylist("12abc34501de35")},
+			input: "12.ABC.345/01DE-35", expectedErr: ErrDenied, expectedCode: CodeDenied},
+		{name: "Not denied", opts: []ValidatorOption{WithDenylist("000.000.001-91")}, input: "716.566.867-59",
+			expectedRaw: "71656686759"},
+		{name: "Invalid before denied", opts: []ValidatorOption{WithDenylist("716.566.867-58")},
+			input: "716.566.867-58", expectedErr: ErrCPFInvalidChecksum, expectedCode: CodeInvalidChecksum},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := NewValidator(tt.opts...)
+			if err != nil {
+				t.Fatalf("NewValidator() error = %v", err)
+			}
+
+			doc, err := v.Parse(tt.input)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.input, err, tt.expectedErr)
+			}
+			if CodeOf(err) != tt.expectedCode {
+				t.Errorf("CodeOf(err) = %q, want %q", CodeOf(err), tt.expectedCode)
+			}
+			if err != nil {
+				if doc != nil {
+					t.Errorf("Parse(%q) = %v on error, want nil", tt.input, doc)
+				}
+				return
+			}
+			if doc.Raw() != tt.expectedRaw {
+				t.Errorf("Parse(%q) = %q, want %q", tt.input, doc.Raw(), tt.expectedRaw)
+			}
+		})
+	}
+}
+
+// Test that ValidateCpf and ValidateCnpj apply the policy for their own document type
+func TestValidator_ValidateKind(t *testing.T) {
+	v, err := NewValidator(WithStrictFormat(), WithAlphanumericCNPJ(false), WithDenylist("22796729000159"))
+	if err != nil {
+		t.Fatalf("NewValidator() error = %v", err)
+	}
+
+	if cpf, err := v.ValidateCpf("716.566.867-59"); err != nil || cpf != "71656686759" {
+		t.Errorf("ValidateCpf() = %q, %v, want the CPF", cpf, err)
+	}
+	if _, err := v.ValidateCpf("22796729000159"); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("ValidateCpf(CNPJ) error = %v, want %v", err, ErrInvalidFormat)
+	}
+	if cnpj, err := v.ValidateCnpj("11.222.333/0001-81"); err != nil || cnpj != "11222333000181" {
+		t.Errorf("ValidateCnpj() = %q, %v, want the CNPJ", cnpj, err)
+	}
+	if _, err := v.ValidateCnpj("716.566.867-59"); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("ValidateCnpj(CPF) error = %v, want %v", err, ErrInvalidFormat)
+	}
+
+	_, err = v.ValidateCnpj("12ABC34501DE35")
+	var verr *ValidationError
+	if !errors.As(err, &verr) || verr.Position != 2 || verr.Char != 'A' || verr.Kind != KindCNPJAlphanumeric {
+		t.Errorf("ValidateCnpj(alphanumeric) error = %#v, want letter 'A' at position 2", err)
+	}
+
+	_, err = v.ValidateCnpj("22.796.729/0001-59")
+	if !errors.Is(err, ErrDenied) || strings.Contains(err.Error(), "22796729000159") {
+		t.Errorf("ValidateCnpj(denied) error = %v, want %v without the document", err, ErrDenied)
+	}
+}
+
+// Test that the lowercase error points into the cleaned input
+func TestValidator_LowercasePosition(t *testing.T) {
+	v, err := NewValidator(WithLowercase(false))
+	if err != nil {
+		t.Fatalf("NewValidator() error = %v", err)
+	}
+
+	_, err = v.ValidateCnpj("12.ABC.345/01dE-35")
+	var verr *ValidationError
+	if !errors.As(err, &verr) || verr.Position != 10 || verr.Char != 'd' {
+		t.Errorf("ValidateCnpj() error = %#v, want 'd' at position 10", err)
+	}
+}
+
+// Test that the zero Validator behaves like the package-level functions
+func TestValidator_Zero(t *testing.T) {
+	var v Validator
+	for _, input := range []string{"716.566.867-59", "12.abc.345/01de-35", "111.111.111-11", "123",
+		strings.Repeat("1", MaxInputSize+1)} {
+		got, gotErr := v.Parse(input)
+		want, wantErr := Parse(input)
+		if got != want || CodeOf(gotErr) != CodeOf(wantErr) {
+			t.Errorf("Parse(%.20q) = %v, %v, want %v, %v", input, got, gotErr, want, wantErr)
+		}
+	}
+}
+
+// Test that out-of-range options are rejected
+func TestNewValidator_InvalidOption(t *testing.T) {
+	for _, n := range []int{0, -1, MaxInputSize + 1} {
+		if _, err := NewValidator(WithMaxInputSize(n)); !errors.Is(err, ErrInvalidOption) {
+			t.Errorf("NewValidator(WithMaxInputSize(%d)) error = %v, want %v", n, err, ErrInvalidOption)
+		}
+	}
+}
+
+// Benchmark parsing with every policy enabled
+func BenchmarkValidator_Parse(b *testing.B) {
+	v, err := NewValidator(WithMaxInputSize(32), WithStrictFormat(), WithLowercase(false),
+		WithDenylist("000.000.001-91"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := v.Parse("12.ABC.345/01DE-35"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}